@@ -0,0 +1,151 @@
+package cfg
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// LayerEnv is the set of environment variables that override config
+	LayerEnv = "env"
+
+	envPrefix = "PIXA"
+)
+
+var envReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// EnvName returns the name of the environment variable that overrides the
+// value at the dotted key path, eg advanced.executable.timeout is
+// overridden by PIXA_ADVANCED_EXECUTABLE_TIMEOUT.
+func EnvName(key string) string {
+	return envPrefix + "_" + strings.ToUpper(envReplacer.Replace(key))
+}
+
+// envKey is a key of the config that may be overridden from the
+// environment, with the schema its value must conform to.
+type envKey struct {
+	key    string
+	schema *Schema
+}
+
+// EnvLayer returns the layer containing the values of the environment
+// variables that correspond to the keys of the config. The keys are
+// derived from the structure of the config (see GenerateSchema), so that
+// a key may be overridden even if no config file defines it, but the
+// entries of collections, whose names are chosen by the user, may not be.
+// The type of each value is that of the key; a list is specified as comma
+// separated values.
+func EnvLayer(lookup func(string) (string, bool)) (*ConfigLayer, error) {
+	keys, err := envKeys(GenerateSchema())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]any)
+
+	for _, name := range sortedKeys(keys) {
+		raw, found := lookup(name)
+
+		if !found {
+			continue
+		}
+
+		value, err := coerceEnv(raw, keys[name].schema)
+		if err != nil {
+			return nil, fmt.Errorf("environment variable: '%v' has invalid value: '%v' (%w)",
+				name, raw, err,
+			)
+		}
+
+		values = withPath(values, keys[name].key, value)
+	}
+
+	return &ConfigLayer{
+		Name:   LayerEnv,
+		Values: values,
+	}, nil
+}
+
+// envKeys returns the leaf keys of the schema indexed by the names of
+// their environment variables. Since '.' and '-' both map to '_', two keys
+// may map to the same name, in which case it would be ambiguous which of
+// them the variable overrides, so this is rejected.
+func envKeys(schema *Schema) (map[string]envKey, error) {
+	keys := make(map[string]envKey)
+
+	var walk func(prefix string, schema *Schema) error
+
+	walk = func(prefix string, schema *Schema) error {
+		for _, name := range sortedKeys(schema.Properties) {
+			key := lo.Ternary(prefix == "", name, prefix+"."+name)
+			property := schema.Properties[name]
+
+			switch {
+			case len(property.Properties) > 0:
+				if err := walk(key, property); err != nil {
+					return err
+				}
+
+				continue
+
+			case property.Type == schemaObject:
+				// a collection
+				continue
+			}
+
+			envName := EnvName(key)
+			if existing, found := keys[envName]; found {
+				return fmt.Errorf("environment variable: '%v' is ambiguous, it maps to '%v' and '%v'",
+					envName, existing.key, key,
+				)
+			}
+
+			keys[envName] = envKey{
+				key:    key,
+				schema: property,
+			}
+		}
+
+		return nil
+	}
+
+	return keys, walk("", schema)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := lo.Keys(m)
+	slices.Sort(keys)
+
+	return keys
+}
+
+func coerceEnv(raw string, schema *Schema) (any, error) {
+	switch schema.Type {
+	case schemaBoolean:
+		return strconv.ParseBool(raw)
+
+	case schemaInteger:
+		value, err := strconv.Atoi(raw)
+		if err == nil && schema.Minimum != nil && value < *schema.Minimum {
+			return nil, fmt.Errorf("must be at least %v", *schema.Minimum)
+		}
+
+		return value, err
+
+	case schemaNumber:
+		return strconv.ParseFloat(raw, 64)
+
+	case schemaArray:
+		items := lo.Map(strings.Split(raw, ","), func(item string, _ int) string {
+			return strings.TrimSpace(item)
+		})
+
+		return lo.ToAnySlice(lo.Compact(items)), nil
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,136 @@
+package cfg_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/cfg"
+)
+
+var _ = Describe("EnvLayer", func() {
+	var (
+		layers cfg.ConfigLayers
+	)
+
+	BeforeEach(func() {
+		defaultLayer, err := cfg.DefaultLayer()
+		Expect(err).To(Succeed())
+
+		layers = cfg.ConfigLayers{defaultLayer}
+	})
+
+	environment := func(vars map[string]string) func(string) (string, bool) {
+		return func(name string) (string, bool) {
+			value, found := vars[name]
+
+			return value, found
+		}
+	}
+
+	Context("given: key", func() {
+		It("🧪 should: map to prefixed environment variable", func() {
+			Expect(cfg.EnvName("advanced.executable.timeout")).To(
+				Equal("PIXA_ADVANCED_EXECUTABLE_TIMEOUT"),
+			)
+			Expect(cfg.EnvName("advanced.target-size.max-attempts")).To(
+				Equal("PIXA_ADVANCED_TARGET_SIZE_MAX_ATTEMPTS"),
+			)
+		})
+	})
+
+	Context("given: environment variables for keys", func() {
+		It("🧪 should: override config with values of the same type", func() {
+			envLayer, err := cfg.EnvLayer(environment(map[string]string{
+				"PIXA_ADVANCED_EXECUTABLE_TIMEOUT":      "7s",
+				"PIXA_ADVANCED_ERROR_POLICY":            "abort",
+				"PIXA_SAMPLER_FILES":                    "5",
+				"PIXA_ADVANCED_TARGET_SIZE_TOLERANCE":   "0.1",
+				"PIXA_ADVANCED_EXECUTABLE_EXTRA_FLAGS":  "resize, define",
+				"PIXA_NOT_A_KEY":                        "foo",
+				"PIXA_ADVANCED_EXECUTABLE_PROGRAM_NAME": "magick",
+			}))
+			Expect(err).To(Succeed())
+
+			merged := append(layers, envLayer).Merge()
+
+			for key, expected := range map[string]any{
+				"advanced.executable.timeout":      "7s",
+				"advanced.error-policy":            "abort",
+				"sampler.files":                    5,
+				"advanced.target-size.tolerance":   0.1,
+				"advanced.executable.extra-flags":  []any{"resize", "define"},
+				"advanced.executable.program-name": "magick",
+			} {
+				value, _ := cfg.ValueAt(merged, key)
+				Expect(value).To(Equal(expected), key)
+			}
+
+			Expect(cfg.LeafKeys(envLayer.Values)).To(HaveLen(6))
+
+			source, _ := append(layers, envLayer).Source("sampler.files")
+			Expect(source.Name).To(Equal(cfg.LayerEnv))
+		})
+	})
+
+	Context("given: environment variable for entry of collection", func() {
+		It("🧪 should: not override config", func() {
+			envLayer, err := cfg.EnvLayer(environment(map[string]string{
+				"PIXA_PROFILES_BLUR_STRIP": "false",
+			}))
+
+			Expect(err).To(Succeed())
+			Expect(envLayer.Values).To(BeEmpty())
+		})
+	})
+
+	Context("given: environment variable with invalid value", func() {
+		It("🧪 should: return error", func() {
+			_, err := cfg.EnvLayer(environment(map[string]string{
+				"PIXA_SAMPLER_FILES": "lots",
+			}))
+
+			Expect(err).To(MatchError(ContainSubstring("PIXA_SAMPLER_FILES")))
+		})
+	})
+
+	Context("given: environment variable with negative value for unsigned key", func() {
+		It("🧪 should: return error", func() {
+			_, err := cfg.EnvLayer(environment(map[string]string{
+				"PIXA_ADVANCED_MAX_FAILURES": "-1",
+			}))
+
+			Expect(err).To(MatchError(ContainSubstring("PIXA_ADVANCED_MAX_FAILURES")))
+		})
+	})
+
+	Context("given: keys that map to the same environment variable", func() {
+		It("🧪 should: reject ambiguous mapping", func() {
+			schema := &cfg.Schema{
+				Properties: map[string]*cfg.Schema{
+					"max-size": {Type: "integer"},
+					"max": {
+						Properties: map[string]*cfg.Schema{
+							"size": {Type: "integer"},
+						},
+					},
+				},
+			}
+
+			_, err := cfg.EnvKeys(schema)
+			Expect(err).To(MatchError(And(
+				ContainSubstring("PIXA_MAX_SIZE"),
+				ContainSubstring("max.size"),
+				ContainSubstring("max-size"),
+			)))
+		})
+	})
+
+	Context("given: structure of config", func() {
+		It("🧪 should: map every key unambiguously", func() {
+			names, err := cfg.EnvKeys(cfg.GenerateSchema())
+
+			Expect(err).To(Succeed())
+			Expect(names).To(ContainElement("PIXA_ADVANCED_TARGET_SIZE_MAX_ATTEMPTS"))
+		})
+	})
+})
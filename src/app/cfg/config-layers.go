@@ -0,0 +1,385 @@
+package cfg
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/snivilised/cobrass/src/assistant/configuration"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// LayerDefault is the config embedded in the pixa binary
+	LayerDefault = "default"
+
+	// LayerUser is the config file found on the search path
+	LayerUser = "user"
+
+	// LayerProject is the config file found in the target directory or
+	// one of its ancestors
+	LayerProject = "project"
+)
+
+// collectionKeys identify the maps whose entries are named by the user,
+// rather than being defined by pixa. Entries of a collection are merged
+// by name, so that an entry in a higher layer replaces the entry of the
+// same name as a whole. The default layer only contributes to a collection
+// that is not defined by any other layer, otherwise the user would find
+// entries in their config that they never asked for.
+var collectionKeys = []string{
+	"profiles",
+	"schemes",
+	"advanced.extensions.map",
+}
+
+// collectionDependencies lists, for a collection, the other collections
+// whose definition also withholds it from the default layer. The default
+// schemes refer to the default profiles, so are of no use without them.
+var collectionDependencies = map[string][]string{
+	"schemes": {"profiles"},
+}
+
+// ConfigLayer is a source of config values. Layers are merged in order of
+// increasing precedence, so that a value in a later layer overrides the
+// same value in an earlier one. Legacy holds the keys of a config that
+// has not been migrated, whose values have been translated into their
+// replacements.
+type ConfigLayer struct {
+	Name   string
+	Path   string
+	Values map[string]any
+	Legacy []string
+}
+
+// DefaultLayer returns the layer for the config embedded in the binary.
+func DefaultLayer() (*ConfigLayer, error) {
+	values, err := parseLayer([]byte(defaultConfig))
+	if err != nil {
+		return nil, fmt.Errorf("embedded config is not valid yaml (%w)", err)
+	}
+
+	return &ConfigLayer{
+		Name:   LayerDefault,
+		Values: values,
+	}, nil
+}
+
+// ReadLayer returns the layer for the config file at path.
+func ReadLayer(vfs storage.VirtualFS, name, path string) (*ConfigLayer, error) {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := parseLayer(content)
+	if err != nil {
+		return nil, fmt.Errorf("config file: '%v' is not valid yaml (%w)", path, err)
+	}
+
+	values, legacy := translateLegacy(values)
+
+	return &ConfigLayer{
+		Name:   name,
+		Path:   path,
+		Values: values,
+		Legacy: legacy,
+	}, nil
+}
+
+// translateLegacy replaces the keys that have been superseded with their
+// replacements, so that a config that has not been migrated behaves as
+// it did before; advanced.abort-on-error becomes the equivalent
+// advanced.error-policy, unless the policy is also defined, in which case
+// the policy prevails (see replaceAbortOnError).
+func translateLegacy(values map[string]any) (map[string]any, []string) {
+	const (
+		abortKey  = "advanced.abort-on-error"
+		policyKey = "advanced.error-policy"
+	)
+
+	abort, found := lookupPath(values, abortKey)
+	if !found {
+		return values, nil
+	}
+
+	values = withoutPath(values, abortKey)
+
+	if _, defined := lookupPath(values, policyKey); !defined {
+		values = withPath(values, policyKey, lo.Ternary(abort == true, "abort", "continue"))
+	}
+
+	return values, []string{abortKey}
+}
+
+// FindProjectConfig looks for the project config file in dir and each of
+// its ancestors in turn, returning the path of the nearest one found.
+func FindProjectConfig(vfs storage.VirtualFS, dir string) (string, bool) {
+	for {
+		path := filepath.Join(dir, common.Definitions.Defaults.Config.ProjectFilename)
+
+		if vfs.FileExists(path) {
+			return path, true
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+
+		dir = parent
+	}
+}
+
+func parseLayer(content []byte) (map[string]any, error) {
+	values := make(map[string]any)
+
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// ConfigLayers is the ordered set of layers that make up the effective
+// config; the embedded default, the user config, the project config and
+// the environment, in order of increasing precedence.
+type ConfigLayers []*ConfigLayer
+
+// Merge returns the values of all the layers merged in order.
+func (layers ConfigLayers) Merge() map[string]any {
+	merged := make(map[string]any)
+
+	for _, layer := range layers {
+		values := layers.contribution(layer)
+		lower := merged
+		merged = mergeValues(merged, values)
+
+		// the entries of a collection are not merged, so the deep merge of
+		// any entry defined by both is undone.
+		//
+		for _, key := range collectionKeys {
+			lowerEntries, isLowerMap := valueAtAsMap(lower, key)
+			upperEntries, isUpperMap := valueAtAsMap(values, key)
+
+			if isLowerMap && isUpperMap {
+				entries := maps.Clone(lowerEntries)
+				maps.Copy(entries, upperEntries)
+				merged = withPath(merged, key, entries)
+			}
+		}
+	}
+
+	return merged
+}
+
+// Source returns the layer that the value at the dotted key path comes
+// from, which is the last layer to define it. For an entry of a collection,
+// the source is the layer that defines the entry.
+func (layers ConfigLayers) Source(key string) (*ConfigLayer, bool) {
+	for _, collection := range collectionKeys {
+		if strings.HasPrefix(key, collection+".") {
+			entry, _, _ := strings.Cut(strings.TrimPrefix(key, collection+"."), ".")
+			key = collection + "." + entry
+		}
+	}
+
+	for i := len(layers) - 1; i >= 0; i-- {
+		if _, found := lookupPath(layers.contribution(layers[i]), key); found {
+			return layers[i], true
+		}
+	}
+
+	return nil, false
+}
+
+// Without returns the layers other than the one with the given name.
+func (layers ConfigLayers) Without(name string) ConfigLayers {
+	return lo.Reject(layers, func(layer *ConfigLayer, _ int) bool {
+		return layer.Name == name
+	})
+}
+
+// Find returns the layer with the given name.
+func (layers ConfigLayers) Find(name string) (*ConfigLayer, bool) {
+	return lo.Find(layers, func(layer *ConfigLayer) bool {
+		return layer.Name == name
+	})
+}
+
+// configReader is the part of viper, absent from the ViperConfig
+// abstraction, that replaces the config that has been read.
+type configReader interface {
+	ReadConfig(in io.Reader) error
+}
+
+// Apply replaces the config held by the viper config with the merged
+// layers. GlobalViperConfig does not expose the means of doing so, but
+// is backed by the global viper instance, so that is read into instead.
+// The file name reported by ConfigFileUsed is unaffected.
+func (layers ConfigLayers) Apply(vc configuration.ViperConfig) error {
+	content, err := yaml.Marshal(layers.Merge())
+	if err != nil {
+		return err
+	}
+
+	switch reader := vc.(type) {
+	case configReader:
+		return reader.ReadConfig(bytes.NewReader(content))
+
+	case *configuration.GlobalViperConfig:
+		return viper.ReadConfig(bytes.NewReader(content))
+	}
+
+	return fmt.Errorf("viper config (%T) does not support reading the merged config", vc)
+}
+
+// contribution returns the values the layer contributes to the merge,
+// which is all of its values, except for the default layer, whose
+// collections are withheld when another layer defines them.
+func (layers ConfigLayers) contribution(layer *ConfigLayer) map[string]any {
+	if layer.Name != LayerDefault {
+		return layer.Values
+	}
+
+	values := layer.Values
+
+	for _, key := range collectionKeys {
+		keys := append([]string{key}, collectionDependencies[key]...)
+		defined := lo.ContainsBy(layers, func(other *ConfigLayer) bool {
+			return other.Name != LayerDefault && lo.ContainsBy(keys, func(k string) bool {
+				_, found := lookupPath(other.Values, k)
+
+				return found
+			})
+		})
+
+		if defined {
+			values = withoutPath(values, key)
+		}
+	}
+
+	return values
+}
+
+// LeafKeys returns the dotted paths of all the leaf values, in order. The
+// entries of a list are not expanded, so a list is a single leaf.
+func LeafKeys(values map[string]any) []string {
+	keys := []string{}
+
+	for key, value := range values {
+		if nested, isMap := value.(map[string]any); isMap && len(nested) > 0 {
+			for _, child := range LeafKeys(nested) {
+				keys = append(keys, key+"."+child)
+			}
+
+			continue
+		}
+
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// ValueAt returns the value at the dotted key path.
+func ValueAt(values map[string]any, key string) (any, bool) {
+	return lookupPath(values, key)
+}
+
+func valueAtAsMap(values map[string]any, key string) (map[string]any, bool) {
+	value, _ := lookupPath(values, key)
+	entries, isMap := value.(map[string]any)
+
+	return entries, isMap
+}
+
+func mergeValues(lower, upper map[string]any) map[string]any {
+	merged := maps.Clone(lower)
+
+	for key, value := range upper {
+		lowerMap, isLowerMap := merged[key].(map[string]any)
+		upperMap, isUpperMap := value.(map[string]any)
+
+		if isLowerMap && isUpperMap {
+			merged[key] = mergeValues(lowerMap, upperMap)
+
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+func lookupPath(values map[string]any, key string) (any, bool) {
+	segments := strings.Split(key, ".")
+	current := values
+
+	for i, segment := range segments {
+		value, found := current[segment]
+		if !found {
+			return nil, false
+		}
+
+		if i == len(segments)-1 {
+			return value, true
+		}
+
+		if current, found = value.(map[string]any); !found {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// withPath returns a copy of values with the value at the dotted key path
+// replaced; the maps along the path are copied, so that values itself is
+// not modified.
+func withPath(values map[string]any, key string, value any) map[string]any {
+	head, rest, nested := strings.Cut(key, ".")
+	result := make(map[string]any, len(values))
+	maps.Copy(result, values)
+
+	if !nested {
+		result[head] = value
+
+		return result
+	}
+
+	child, _ := result[head].(map[string]any)
+	result[head] = withPath(child, rest, value)
+
+	return result
+}
+
+// withoutPath returns a copy of values without the value at the dotted key
+// path.
+func withoutPath(values map[string]any, key string) map[string]any {
+	head, rest, nested := strings.Cut(key, ".")
+	result := maps.Clone(values)
+
+	if !nested {
+		delete(result, head)
+
+		return result
+	}
+
+	if child, isMap := result[head].(map[string]any); isMap {
+		result[head] = withoutPath(child, rest)
+	}
+
+	return result
+}
@@ -0,0 +1,248 @@
+package cfg_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+	"github.com/spf13/viper"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+var _ = Describe("ConfigLayers", func() {
+	var (
+		layers cfg.ConfigLayers
+		user   *cfg.ConfigLayer
+	)
+
+	BeforeEach(func() {
+		vfs := storage.UseNativeFS()
+		path := filepath.Join(GinkgoT().TempDir(), "pixa.yml")
+		content := `
+profiles:
+  mine:
+    strip: true
+advanced:
+  executable:
+    program-name: magick
+`
+		Expect(vfs.WriteFile(path, []byte(content), common.Permissions.Write)).To(Succeed())
+
+		defaultLayer, err := cfg.DefaultLayer()
+		Expect(err).To(Succeed())
+
+		user, err = cfg.ReadLayer(vfs, cfg.LayerUser, path)
+		Expect(err).To(Succeed())
+
+		layers = cfg.ConfigLayers{defaultLayer, user}
+	})
+
+	Context("given: value defined in user config", func() {
+		It("🧪 should: override default", func() {
+			value, found := cfg.ValueAt(layers.Merge(), "advanced.executable.program-name")
+
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("magick"))
+
+			source, _ := layers.Source("advanced.executable.program-name")
+			Expect(source).To(Equal(user))
+		})
+	})
+
+	Context("given: value missing from user config", func() {
+		It("🧪 should: take default", func() {
+			value, found := cfg.ValueAt(layers.Merge(), "advanced.executable.timeout")
+
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("20s"))
+
+			source, _ := layers.Source("advanced.executable.timeout")
+			Expect(source.Name).To(Equal(cfg.LayerDefault))
+		})
+	})
+
+	Context("given: collection defined in user config", func() {
+		It("🧪 should: not contain default entries", func() {
+			merged := layers.Merge()
+
+			strip, _ := cfg.ValueAt(merged, "profiles.mine.strip")
+			Expect(strip).To(BeTrue())
+
+			_, found := cfg.ValueAt(merged, "profiles.blur")
+			Expect(found).To(BeFalse())
+
+			_, found = cfg.ValueAt(merged, "schemes.blur-sf")
+			Expect(found).To(BeFalse())
+		})
+
+		It("🧪 should: attribute entry to its layer", func() {
+			source, found := layers.Source("profiles.mine.strip")
+
+			Expect(found).To(BeTrue())
+			Expect(source).To(Equal(user))
+		})
+	})
+
+	Context("given: later layer with entry of collection", func() {
+		It("🧪 should: replace entry of the same name as a whole", func() {
+			later := &cfg.ConfigLayer{
+				Name: "later",
+				Values: map[string]any{
+					"profiles": map[string]any{
+						"mine": map[string]any{
+							"quality": 80,
+						},
+						"yours": map[string]any{
+							"strip": false,
+						},
+					},
+				},
+			}
+			merged := append(layers, later).Merge()
+
+			mine, _ := cfg.ValueAt(merged, "profiles.mine")
+			Expect(mine).To(Equal(map[string]any{
+				"quality": 80,
+			}))
+
+			_, found := cfg.ValueAt(merged, "profiles.yours.strip")
+			Expect(found).To(BeTrue())
+		})
+	})
+
+	Context("given: merged values", func() {
+		It("🧪 should: return leaf keys in order", func() {
+			keys := cfg.LeafKeys(layers.Merge())
+
+			Expect(keys).To(ContainElement("advanced.executable.extra-flags"))
+			Expect(keys).To(ContainElement("profiles.mine.strip"))
+			Expect(slices.IsSorted(keys)).To(BeTrue())
+			Expect(keys[0]).To(Equal("advanced.error-policy"))
+		})
+	})
+
+	Context("given: project config", func() {
+		It("🧪 should: override user config", func() {
+			project := &cfg.ConfigLayer{
+				Name: cfg.LayerProject,
+				Path: "/project/.pixa.yml",
+				Values: map[string]any{
+					"advanced": map[string]any{
+						"executable": map[string]any{
+							"timeout": "5s",
+						},
+					},
+				},
+			}
+			layers = append(layers, project)
+			merged := layers.Merge()
+
+			timeout, _ := cfg.ValueAt(merged, "advanced.executable.timeout")
+			Expect(timeout).To(Equal("5s"))
+
+			name, _ := cfg.ValueAt(merged, "advanced.executable.program-name")
+			Expect(name).To(Equal("magick"))
+
+			source, _ := layers.Source("advanced.executable.timeout")
+			Expect(source).To(Equal(project))
+		})
+	})
+
+	Context("given: viper config not backed by global viper", func() {
+		It("🧪 should: apply merged layers to that config", func() {
+			vc := viper.New()
+			vc.SetConfigType("yaml")
+
+			Expect(layers.Apply(vc)).To(Succeed())
+			Expect(vc.GetString("advanced.executable.program-name")).To(Equal("magick"))
+			Expect(vc.GetString("advanced.executable.timeout")).To(Equal("20s"))
+			Expect(viper.GetString("advanced.executable.program-name")).NotTo(Equal("magick"))
+		})
+	})
+})
+
+var _ = Describe("FindProjectConfig", func() {
+	var (
+		vfs  storage.VirtualFS
+		root string
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		root = GinkgoT().TempDir()
+		Expect(vfs.MkdirAll(filepath.Join(root, "a", "b"), common.Permissions.Write)).To(Succeed())
+	})
+
+	Context("given: config in ancestor directory", func() {
+		It("🧪 should: find nearest config", func() {
+			path := filepath.Join(root, "a", common.Definitions.Defaults.Config.ProjectFilename)
+			Expect(vfs.WriteFile(path, []byte("{}\n"), common.Permissions.Write)).To(Succeed())
+
+			found, ok := cfg.FindProjectConfig(vfs, filepath.Join(root, "a", "b"))
+
+			Expect(ok).To(BeTrue())
+			Expect(found).To(Equal(path))
+		})
+	})
+
+	Context("given: no config in directory or ancestors", func() {
+		It("🧪 should: not find config", func() {
+			_, ok := cfg.FindProjectConfig(vfs, filepath.Join(root, "a", "b"))
+
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
+
+type legacyTE struct {
+	given    string
+	content  string
+	expected string
+}
+
+var _ = Describe("ReadLayer", func() {
+	DescribeTable("legacy abort-on-error",
+		func(entry *legacyTE) {
+			vfs := storage.UseNativeFS()
+			path := filepath.Join(GinkgoT().TempDir(), "pixa.yml")
+			Expect(vfs.WriteFile(path, []byte(entry.content), common.Permissions.Write)).To(Succeed())
+
+			defaultLayer, err := cfg.DefaultLayer()
+			Expect(err).To(Succeed())
+
+			user, err := cfg.ReadLayer(vfs, cfg.LayerUser, path)
+			Expect(err).To(Succeed())
+			Expect(user.Legacy).To(ConsistOf("advanced.abort-on-error"))
+
+			merged := cfg.ConfigLayers{defaultLayer, user}.Merge()
+			policy, _ := cfg.ValueAt(merged, "advanced.error-policy")
+			Expect(policy).To(Equal(entry.expected))
+
+			_, found := cfg.ValueAt(merged, "advanced.abort-on-error")
+			Expect(found).To(BeFalse())
+		},
+		func(entry *legacyTE) string {
+			return fmt.Sprintf("🧪 ===> given: '%v', should: use '%v' policy", entry.given, entry.expected)
+		},
+		Entry(nil, &legacyTE{
+			given:    "abort-on-error enabled",
+			content:  "advanced:\n  abort-on-error: true\n",
+			expected: "abort",
+		}),
+		Entry(nil, &legacyTE{
+			given:    "abort-on-error disabled",
+			content:  "advanced:\n  abort-on-error: false\n",
+			expected: "continue",
+		}),
+		Entry(nil, &legacyTE{
+			given:    "error-policy also defined",
+			content:  "advanced:\n  abort-on-error: true\n  error-policy: quarantine\n",
+			expected: "quarantine",
+		}),
+	)
+})
@@ -0,0 +1,251 @@
+package cfg
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// LintIssue is a problem found in a config file. Line is 0 for a problem
+// that can't be attributed to a particular line, eg a profile that
+// extends a profile defined in another file.
+type LintIssue struct {
+	Line    int
+	Column  int
+	Key     string
+	Message string
+}
+
+func (i LintIssue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+
+	return fmt.Sprintf("line %v, column %v: %v", i.Line, i.Column, i.Message)
+}
+
+// Lint checks the config file at path against the schema, reporting
+// unknown keys, values of the wrong type and invalid suffixes, along with
+// the line they appear on. Only when the file conforms to the schema is
+// it validated in the same way as it would be when pixa runs; ie merged
+// over the embedded default config.
+func Lint(vfs storage.VirtualFS, path string) ([]LintIssue, error) {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := readMapping(path, content)
+	if err != nil {
+		return []LintIssue{{Message: err.Error()}}, nil //nolint:nilerr // reported as issue
+	}
+
+	root := doc.Content[0]
+	issues := lintNode(root, GenerateSchema(), "")
+	issues = append(issues, lintSuffixes(root)...)
+
+	if len(issues) == 0 {
+		if err := validateContent(content); err != nil {
+			issues = append(issues, LintIssue{Message: err.Error()})
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b LintIssue) int {
+		return a.Line - b.Line
+	})
+
+	return issues, nil
+}
+
+func lintNode(node *yaml.Node, schema *Schema, key string) []LintIssue {
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		// decodes to the zero value
+		//
+		return nil
+	}
+
+	issues := []LintIssue{}
+	mismatch := func(expected string) []LintIssue {
+		return append(issues, LintIssue{
+			Line:    node.Line,
+			Column:  node.Column,
+			Key:     key,
+			Message: fmt.Sprintf("'%v' expects %v, found: '%v'", key, expected, describeNode(node)),
+		})
+	}
+
+	switch schema.Type {
+	case schemaObject:
+		if node.Kind != yaml.MappingNode {
+			return mismatch("a map")
+		}
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			keyNode, valueNode := node.Content[i], node.Content[i+1]
+			path := strings.TrimPrefix(key+"."+keyNode.Value, ".")
+			child, found := schema.Properties[keyNode.Value]
+
+			if !found {
+				elem, isMap := schema.AdditionalProperties.(*Schema)
+
+				if !isMap {
+					issues = append(issues, LintIssue{
+						Line:    keyNode.Line,
+						Column:  keyNode.Column,
+						Key:     path,
+						Message: fmt.Sprintf("unknown key: '%v'", path),
+					})
+
+					continue
+				}
+
+				child = elem
+			}
+
+			issues = append(issues, lintNode(valueNode, child, path)...)
+		}
+
+	case schemaArray:
+		if node.Kind != yaml.SequenceNode {
+			return mismatch("a list")
+		}
+
+		for i, item := range node.Content {
+			issues = append(issues, lintNode(item, schema.Items, fmt.Sprintf("%v[%v]", key, i))...)
+		}
+
+	case schemaString:
+		if node.Kind != yaml.ScalarNode {
+			return mismatch("a string")
+		}
+
+	case schemaBoolean:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			return mismatch("a boolean")
+		}
+
+	case schemaNumber:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			return mismatch("a number")
+		}
+
+	case schemaInteger:
+		value, err := strconv.Atoi(node.Value)
+
+		if node.Kind != yaml.ScalarNode || err != nil {
+			return mismatch("an integer")
+		}
+
+		if schema.Minimum != nil && value < *schema.Minimum {
+			return mismatch(fmt.Sprintf("an integer of at least %v", *schema.Minimum))
+		}
+	}
+
+	return issues
+}
+
+func describeNode(node *yaml.Node) string {
+	switch node.Kind { //nolint:exhaustive // other kinds are described by their value
+	case yaml.MappingNode:
+		return "map"
+
+	case yaml.SequenceNode:
+		return "list"
+	}
+
+	return node.Value
+}
+
+// lintSuffixes checks the suffixes of the extensions config, reporting the
+// line of each invalid suffix.
+func lintSuffixes(root *yaml.Node) []LintIssue {
+	issues := []LintIssue{}
+	extensions := lookup(lookupMapping(root, "advanced"), "extensions")
+
+	if extensions == nil || extensions.Kind != yaml.MappingNode {
+		return issues
+	}
+
+	check := func(node *yaml.Node, suffix, from string) {
+		if err := validateSuffixes([]string{suffix}, from); err != nil {
+			issues = append(issues, LintIssue{
+				Line:    node.Line,
+				Column:  node.Column,
+				Key:     "advanced.extensions." + from,
+				Message: err.Error(),
+			})
+		}
+	}
+
+	if csv := lookup(extensions, "suffixes-csv"); csv != nil && csv.Kind == yaml.ScalarNode {
+		for _, suffix := range strings.Split(csv.Value, ",") {
+			check(csv, strings.TrimSpace(suffix), "suffixes-csv")
+		}
+	}
+
+	if mapping := lookup(extensions, "map"); mapping != nil && mapping.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			check(mapping.Content[i], mapping.Content[i].Value, "map/keys")
+			check(mapping.Content[i+1], mapping.Content[i+1].Value, "map/values")
+		}
+	}
+
+	return issues
+}
+
+func lookupMapping(parent *yaml.Node, key string) *yaml.Node {
+	if node := lookup(parent, key); node != nil && node.Kind == yaml.MappingNode {
+		return node
+	}
+
+	return &yaml.Node{
+		Kind: yaml.MappingNode,
+	}
+}
+
+// validateContent validates the config content merged over the embedded
+// default config. A separate viper instance is used, so that the config
+// in use is left intact.
+func validateContent(content []byte) error {
+	defaultLayer, err := DefaultLayer()
+	if err != nil {
+		return err
+	}
+
+	values, err := parseLayer(content)
+	if err != nil {
+		return err
+	}
+
+	merged, err := yaml.Marshal(ConfigLayers{
+		defaultLayer, {Name: LayerUser, Values: values},
+	}.Merge())
+	if err != nil {
+		return err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	if err := v.ReadConfig(bytes.NewReader(merged)); err != nil {
+		return err
+	}
+
+	var (
+		m MsMasterConfig
+	)
+
+	if err := v.Unmarshal(&m); err != nil {
+		return err
+	}
+
+	_, err = m.build()
+
+	return err
+}
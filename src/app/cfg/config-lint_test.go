@@ -0,0 +1,109 @@
+package cfg_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/internal/helpers"
+)
+
+var _ = Describe("Lint", func() {
+	var (
+		vfs  storage.VirtualFS
+		path string
+	)
+
+	BeforeEach(func() {
+		xi18n.ResetTx()
+		Expect(helpers.UseI18n(helpers.Path(helpers.Repo(""), "test/data/l10n"))).To(Succeed())
+
+		vfs = storage.UseNativeFS()
+		path = filepath.Join(GinkgoT().TempDir(), "pixa.yml")
+	})
+
+	lint := func(content string) []cfg.LintIssue {
+		Expect(vfs.WriteFile(path, []byte(content), common.Permissions.Write)).To(Succeed())
+
+		issues, err := cfg.Lint(vfs, path)
+		Expect(err).To(Succeed())
+
+		return issues
+	}
+
+	Context("given: default config", func() {
+		It("🧪 should: not report any issues", func() {
+			Expect(lint(cfg.GetDefaultConfigContent())).To(BeEmpty())
+		})
+	})
+
+	Context("given: config with schema violations", func() {
+		It("🧪 should: report each with its line", func() {
+			issues := lint(`sampler:
+  files: -2
+  foldrs: 1
+advanced:
+  max-failures: maybe
+  extensions:
+    suffixes-csv: "jpg,bmpx"
+  target-size:
+    max-attempts: [1]
+`)
+
+			Expect(issues).To(Equal([]cfg.LintIssue{
+				{Line: 2, Column: 10, Key: "sampler.files",
+					Message: "'sampler.files' expects an integer of at least 0, found: '-2'",
+				},
+				{Line: 3, Column: 3, Key: "sampler.foldrs",
+					Message: "unknown key: 'sampler.foldrs'",
+				},
+				{Line: 5, Column: 17, Key: "advanced.max-failures",
+					Message: "'advanced.max-failures' expects an integer, found: 'maybe'",
+				},
+				{Line: 7, Column: 19, Key: "advanced.extensions.suffixes-csv",
+					Message: "invalid formats found (suffixes-csv): 'bmpx'",
+				},
+				{Line: 9, Column: 19, Key: "advanced.target-size.max-attempts",
+					Message: "'advanced.target-size.max-attempts' expects an integer, found: 'list'",
+				},
+			}))
+		})
+	})
+
+	Context("given: config that conforms to schema, but is invalid", func() {
+		It("🧪 should: report validation error", func() {
+			issues := lint("profiles:\n  blur:\n    bogus: 1\n")
+
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Line).To(Equal(0))
+			Expect(issues[0].Message).To(ContainSubstring("unknown flag: 'bogus'"))
+		})
+
+		It("🧪 should: report invalid error policy", func() {
+			issues := lint("advanced:\n  error-policy: explode\n")
+
+			Expect(issues).To(HaveLen(1))
+			Expect(issues[0].Message).To(ContainSubstring("invalid error-policy found: 'explode'"))
+		})
+	})
+})
+
+var _ = Describe("GenerateSchema", func() {
+	It("🧪 should: describe config from its structs", func() {
+		schema := cfg.GenerateSchema()
+
+		Expect(schema.Type).To(Equal("object"))
+		Expect(schema.AdditionalProperties).To(BeFalse())
+		Expect(schema.Properties).To(HaveKey("profiles"))
+
+		executable := schema.Properties["advanced"].Properties["executable"]
+		Expect(executable.Properties["extra-flags"].Type).To(Equal("array"))
+		Expect(executable.Properties["no-retries"].Type).To(Equal("integer"))
+		Expect(*executable.Properties["no-retries"].Minimum).To(Equal(0))
+	})
+})
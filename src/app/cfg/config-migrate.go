@@ -0,0 +1,185 @@
+package cfg
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// migrationFn upgrades the config document in place, returning a
+	// description of each change made.
+	migrationFn func(doc, defaults *yaml.Node) []string
+
+	migration struct {
+		name    string
+		migrate migrationFn
+	}
+
+	// MigrationResult describes the outcome of migrating a config file;
+	// the changes made and the path of the backup of the original file,
+	// which is empty if the file did not need to be changed.
+	MigrationResult struct {
+		Changes []string
+		Backup  string
+	}
+)
+
+// migrations are applied in order, so that a migration may depend on
+// the layout produced by the ones before it.
+var migrations = []migration{
+	{
+		name:    "replace-abort-on-error",
+		migrate: replaceAbortOnError,
+	},
+	{
+		name:    "remove-obsolete-keys",
+		migrate: removeObsoleteKeys,
+	},
+	{
+		name:    "add-missing-keys",
+		migrate: addMissingKeys,
+	},
+}
+
+// obsoleteKeys are keys that used to appear in the default config, but
+// are not part of the config layout, so would be reported by lint as
+// unknown.
+var obsoleteKeys = []string{
+	"advanced.overwrite-on-collision",
+}
+
+// Migrate upgrades the config file at path to the layout of the embedded
+// default config. As with SetDefaultProfile, the document is modified in
+// place so that the user's comments and ordering are retained. The
+// original file is backed up alongside it before being overwritten. When
+// dryRun is set, the changes are reported but nothing is written.
+func Migrate(vfs storage.VirtualFS, path string, dryRun bool) (*MigrationResult, error) {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := readMapping(path, content)
+	if err != nil {
+		return nil, err
+	}
+
+	defaults, err := readMapping("embedded config", []byte(defaultConfig))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MigrationResult{}
+
+	for _, m := range migrations {
+		for _, change := range m.migrate(doc.Content[0], defaults.Content[0]) {
+			result.Changes = append(result.Changes, fmt.Sprintf("%v: %v", m.name, change))
+		}
+	}
+
+	if len(result.Changes) == 0 || dryRun {
+		return result, nil
+	}
+
+	result.Backup = fmt.Sprintf("%v.%v.bak", path, time.Now().Format("20060102-150405"))
+
+	if err = vfs.WriteFile(result.Backup, content, common.Permissions.Write); err != nil {
+		return nil, err
+	}
+
+	return result, writeDocument(vfs, path, doc)
+}
+
+func removeObsoleteKeys(doc, _ *yaml.Node) []string {
+	changes := []string{}
+
+	for _, key := range obsoleteKeys {
+		segments := strings.Split(key, ".")
+		parent := doc
+
+		for _, segment := range segments[:len(segments)-1] {
+			if parent = lookup(parent, segment); parent == nil || parent.Kind != yaml.MappingNode {
+				break
+			}
+		}
+
+		if parent != nil && parent.Kind == yaml.MappingNode && remove(parent, segments[len(segments)-1]) {
+			changes = append(changes, fmt.Sprintf("removed '%v'", key))
+		}
+	}
+
+	return changes
+}
+
+// replaceAbortOnError replaces advanced.abort-on-error with the equivalent
+// advanced.error-policy, in the same position. It has to precede
+// add-missing-keys, otherwise the default policy would be added instead.
+func replaceAbortOnError(doc, _ *yaml.Node) []string {
+	advanced := lookup(doc, "advanced")
+	if advanced == nil || advanced.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(advanced.Content); i += 2 {
+		if advanced.Content[i].Value != "abort-on-error" {
+			continue
+		}
+
+		if lookup(advanced, "error-policy") != nil {
+			remove(advanced, "abort-on-error")
+
+			return []string{"removed 'advanced.abort-on-error'"}
+		}
+
+		policy := lo.Ternary(advanced.Content[i+1].Value == "true", "abort", "continue")
+		advanced.Content[i].Value = "error-policy"
+		advanced.Content[i+1] = &yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Tag:   "!!str",
+			Value: policy,
+		}
+
+		return []string{
+			fmt.Sprintf("replaced 'advanced.abort-on-error' with 'advanced.error-policy: %v'", policy),
+		}
+	}
+
+	return nil
+}
+
+// addMissingKeys adds the keys of the default config that are absent from
+// the document. Collections are only added when absent as a whole; the
+// entries of a collection belong to the user, so are never added to.
+func addMissingKeys(doc, defaults *yaml.Node) []string {
+	return addMissing(doc, defaults, "")
+}
+
+func addMissing(target, source *yaml.Node, prefix string) []string {
+	changes := []string{}
+
+	for i := 0; i+1 < len(source.Content); i += 2 {
+		key := source.Content[i].Value
+		path := strings.TrimPrefix(prefix+"."+key, ".")
+		value := source.Content[i+1]
+		existing := lookup(target, key)
+
+		switch {
+		case existing == nil:
+			assign(target, key, value)
+			changes = append(changes, fmt.Sprintf("added '%v'", path))
+
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode &&
+			!slices.Contains(collectionKeys, path):
+			changes = append(changes, addMissing(existing, value, path)...)
+		}
+	}
+
+	return changes
+}
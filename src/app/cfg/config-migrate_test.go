@@ -0,0 +1,100 @@
+package cfg_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+const legacyConfig = `# my profiles
+profiles:
+  sf:
+    strip: true
+advanced:
+  overwrite-on-collision: false
+  executable:
+    program-name: magick
+`
+
+var _ = Describe("Migrate", func() {
+	var (
+		vfs  storage.VirtualFS
+		path string
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		path = filepath.Join(GinkgoT().TempDir(), "pixa.yml")
+		Expect(vfs.WriteFile(path, []byte(legacyConfig), common.Permissions.Write)).To(Succeed())
+	})
+
+	Context("given: config with missing keys", func() {
+		It("🧪 should: add missing keys, retain comments and backup original", func() {
+			result, err := cfg.Migrate(vfs, path, false)
+
+			Expect(err).To(Succeed())
+			Expect(result.Changes).To(ContainElements(
+				"add-missing-keys: added 'advanced.executable.extra-flags'",
+				"add-missing-keys: added 'advanced.target-size'",
+			))
+			Expect(result.Changes).NotTo(ContainElement(ContainSubstring("profiles")))
+			Expect(result.Changes[0]).To(Equal("remove-obsolete-keys: removed 'advanced.overwrite-on-collision'"))
+
+			written, _ := vfs.ReadFile(path)
+			Expect(string(written)).To(HavePrefix("# my profiles\nprofiles:\n  sf:\n    strip: true\n"))
+			Expect(string(written)).To(ContainSubstring("max-attempts: 8"))
+			Expect(string(written)).NotTo(ContainSubstring("gaussian-blur"))
+			Expect(string(written)).NotTo(ContainSubstring("overwrite-on-collision"))
+
+			backup, _ := vfs.ReadFile(result.Backup)
+			Expect(string(backup)).To(Equal(legacyConfig))
+		})
+
+		It("🧪 should: be up to date after migration", func() {
+			_, err := cfg.Migrate(vfs, path, false)
+			Expect(err).To(Succeed())
+
+			result, err := cfg.Migrate(vfs, path, false)
+			Expect(err).To(Succeed())
+			Expect(result.Changes).To(BeEmpty())
+			Expect(result.Backup).To(BeEmpty())
+		})
+	})
+
+	Context("given: config with abort-on-error", func() {
+		It("🧪 should: replace with equivalent error-policy", func() {
+			Expect(vfs.WriteFile(path,
+				[]byte("advanced:\n  abort-on-error: true\n  max-failures: 3\n"), common.Permissions.Write,
+			)).To(Succeed())
+
+			result, err := cfg.Migrate(vfs, path, false)
+
+			Expect(err).To(Succeed())
+			Expect(result.Changes[0]).To(Equal(
+				"replace-abort-on-error: replaced 'advanced.abort-on-error' with 'advanced.error-policy: abort'",
+			))
+			Expect(result.Changes).NotTo(ContainElement(ContainSubstring("added 'advanced.error-policy'")))
+
+			written, _ := vfs.ReadFile(path)
+			Expect(string(written)).To(HavePrefix("advanced:\n  error-policy: abort\n  max-failures: 3\n"))
+		})
+	})
+
+	Context("given: dry run", func() {
+		It("🧪 should: report changes without modifying config", func() {
+			result, err := cfg.Migrate(vfs, path, true)
+
+			Expect(err).To(Succeed())
+			Expect(result.Changes).NotTo(BeEmpty())
+			Expect(result.Backup).To(BeEmpty())
+
+			written, _ := vfs.ReadFile(path)
+			Expect(string(written)).To(Equal(legacyConfig))
+		})
+	})
+})
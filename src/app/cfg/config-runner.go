@@ -2,6 +2,7 @@ package cfg
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -71,7 +72,9 @@ func (c *configRunner) Run() error {
 
 	err := c.read()
 
-	c.handleLangSetting(c.vc)
+	if langErr := c.handleLangSetting(c.vc); langErr != nil {
+		return langErr
+	}
 
 	return err
 }
@@ -119,42 +122,13 @@ func (c *configRunner) read() error {
 		func() error {
 			// try standard or XDG
 			//
-			if c.useXDG {
-				// manual XDG: ["~/.local/share/app", "/usr/local/share/app", "/usr/share/app"]
-				// https://github.com/muesli/go-app-paths?tab=readme-ov-file#directories
-				//
-				paths := []string{
-					filepath.Join(c.home, ".local", "share"),
-					filepath.Join(string(filepath.Separator), "usr", "local", "share"),
-					filepath.Join(string(filepath.Separator), "usr", "share"),
-				}
-
-				for _, dir := range paths {
-					c.vc.AddConfigPath(filepath.Join(dir, common.Definitions.Pixa.AppName))
-				}
-			} else {
-				// use standard muesli; ie platform specific
-				//
-				scope := lo.TernaryF(c.ci.Scope != nil,
-					func() common.ConfigScope {
-						return c.ci.Scope
-					},
-					func() common.ConfigScope {
-						return gap.NewVendorScope(gap.User,
-							common.Definitions.Pixa.Org, common.Definitions.Pixa.AppName,
-						)
-					},
-				)
-
-				paths, e := scope.ConfigDirs()
-
-				if e == nil {
-					for _, dir := range paths {
-						c.vc.AddConfigPath(dir)
-					}
-				} else {
-					return e
-				}
+			paths, e := c.standardPaths()
+			if e != nil {
+				return e
+			}
+
+			for _, dir := range paths {
+				c.vc.AddConfigPath(dir)
 			}
 
 			return nil
@@ -186,9 +160,94 @@ func (c *configRunner) read() error {
 	return err
 }
 
+// standardPaths returns the XDG paths when XDG is in use, otherwise the
+// platform specific paths.
+func (c *configRunner) standardPaths() ([]string, error) {
+	if c.useXDG {
+		// manual XDG: ["~/.local/share/app", "/usr/local/share/app", "/usr/share/app"]
+		// https://github.com/muesli/go-app-paths?tab=readme-ov-file#directories
+		//
+		paths := []string{
+			filepath.Join(c.home, ".local", "share"),
+			filepath.Join(string(filepath.Separator), "usr", "local", "share"),
+			filepath.Join(string(filepath.Separator), "usr", "share"),
+		}
+
+		return lo.Map(paths, func(dir string, _ int) string {
+			return filepath.Join(dir, common.Definitions.Pixa.AppName)
+		}), nil
+	}
+
+	// use standard muesli; ie platform specific
+	//
+	return c.scope().ConfigDirs()
+}
+
+func (c *configRunner) scope() common.ConfigScope {
+	return lo.TernaryF(c.ci.Scope != nil,
+		func() common.ConfigScope {
+			return c.ci.Scope
+		},
+		func() common.ConfigScope {
+			return gap.NewVendorScope(gap.User,
+				common.Definitions.Pixa.Org, common.Definitions.Pixa.AppName,
+			)
+		},
+	)
+}
+
+// SearchPaths returns the directories searched for the config file, in
+// the order they are searched; the first directory containing the file
+// wins.
+func (c *configRunner) SearchPaths() ([]string, error) {
+	paths, err := c.standardPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	return lo.Uniq(append([]string{c.path(), c.home}, paths...)), nil
+}
+
+// ScopePath returns the directory that represents the scope, which is
+// where 'config init' writes the config file.
+func (c *configRunner) ScopePath(scope common.ConfigScopeEnum) (string, error) {
+	switch scope {
+	case common.ConfigScopeDefaultEn:
+		return c.DefaultPath(), nil
+
+	case common.ConfigScopeHomeEn:
+		return c.home, nil
+
+	case common.ConfigScopeXDGEn:
+		return filepath.Join(c.home, ".local", "share", common.Definitions.Pixa.AppName), nil
+
+	case common.ConfigScopePlatformEn:
+		paths, err := c.scope().ConfigDirs()
+		if err != nil {
+			return "", err
+		}
+
+		if len(paths) == 0 {
+			return "", errors.New("platform does not define any config directories")
+		}
+
+		return paths[0], nil
+
+	case common.ConfigScopeEnvEn:
+		path, _ := c.vc.Get(common.Definitions.Environment.Home).(string)
+		if path == "" {
+			return "", fmt.Errorf("%v not defined in environment", common.Definitions.Environment.Home)
+		}
+
+		return path, nil
+	}
+
+	return "", fmt.Errorf("unknown config scope: '%v'", scope)
+}
+
 func (c *configRunner) export() error {
 	path := c.DefaultPath()
-	file := filepath.Join(path, common.Definitions.Pixa.ConfigType)
+	file := filepath.Join(path, common.Definitions.Defaults.Config.ConfigFilename)
 	content := []byte(defaultConfig)
 
 	if !c.vfs.FileExists(file) {
@@ -202,25 +261,27 @@ func (c *configRunner) export() error {
 	return nil
 }
 
-func (c *configRunner) handleLangSetting(config configuration.ViperConfig) {
-	tag := lo.TernaryF(config.InConfig("lang"),
-		func() language.Tag {
-			lang := viper.GetString("lang")
-			parsedTag, err := language.Parse(lang)
+// handleLangSetting initialises i18n with the language defined in config.
+// An invalid language is reported only once i18n has been initialised with
+// the default language, so that the error can be localised.
+func (c *configRunner) handleLangSetting(config configuration.ViperConfig) error {
+	var (
+		langErr error
+	)
 
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
+	tag := xi18n.DefaultLanguage.Get()
 
-			return parsedTag
-		},
-		func() language.Tag {
-			return xi18n.DefaultLanguage.Get()
-		},
-	)
+	if config.InConfig("lang") {
+		lang := viper.GetString("lang")
 
-	err := xi18n.Use(func(uo *xi18n.UseOptions) {
+		if parsedTag, err := language.Parse(lang); err == nil {
+			tag = parsedTag
+		} else {
+			langErr = fmt.Errorf("lang: '%v' (%w)", lang, err)
+		}
+	}
+
+	if err := xi18n.Use(func(uo *xi18n.UseOptions) {
 		uo.Tag = tag
 		uo.From = xi18n.LoadFrom{
 			Sources: xi18n.TranslationFiles{
@@ -232,10 +293,9 @@ func (c *configRunner) handleLangSetting(config configuration.ViperConfig) {
 				},
 			},
 		}
-	})
-
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	}); err != nil {
+		return err
 	}
+
+	return langErr
 }
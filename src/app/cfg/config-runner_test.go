@@ -37,6 +37,10 @@ func (f *testScope) LogPath(filename string) (string, error) {
 	return filename, nil
 }
 
+func (f *testScope) DataPath(filename string) (string, error) {
+	return filename, nil
+}
+
 type errorScope struct {
 }
 
@@ -48,6 +52,10 @@ func (f *errorScope) LogPath(filename string) (string, error) {
 	return filename, nil
 }
 
+func (f *errorScope) DataPath(filename string) (string, error) {
+	return filename, nil
+}
+
 type runnerTE struct {
 	given   string
 	should  string
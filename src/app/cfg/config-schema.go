@@ -0,0 +1,110 @@
+package cfg
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+	schemaObject  = "object"
+	schemaArray   = "array"
+	schemaString  = "string"
+	schemaInteger = "integer"
+	schemaNumber  = "number"
+	schemaBoolean = "boolean"
+)
+
+// Schema is the subset of JSON Schema required to describe the config.
+// AdditionalProperties is either false, for an object whose properties
+// are fixed, or the schema of the values of a map.
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Minimum              *int               `json:"minimum,omitempty"`
+}
+
+// GenerateSchema returns the JSON Schema of the config file, derived from
+// the mapstructure tags of MsMasterConfig, so that it can't drift from the
+// config that is actually decoded.
+func GenerateSchema() *Schema {
+	schema := schemaOf(reflect.TypeOf(MsMasterConfig{}))
+	schema.Dialect = schemaDialect
+	schema.Title = "pixa config"
+
+	return schema
+}
+
+func schemaOf(t reflect.Type) *Schema {
+	switch t.Kind() { //nolint:exhaustive // remaining kinds do not appear in config
+	case reflect.Struct:
+		schema := &Schema{
+			Type:                 schemaObject,
+			Properties:           make(map[string]*Schema, t.NumField()),
+			AdditionalProperties: false,
+		}
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+
+			if !field.IsExported() || name == "" {
+				continue
+			}
+
+			schema.Properties[name] = schemaOf(field.Type)
+		}
+
+		return schema
+
+	case reflect.Map:
+		return &Schema{
+			Type:                 schemaObject,
+			AdditionalProperties: schemaOf(t.Elem()),
+		}
+
+	case reflect.Slice:
+		return &Schema{
+			Type:  schemaArray,
+			Items: schemaOf(t.Elem()),
+		}
+
+	case reflect.String:
+		return &Schema{
+			Type: schemaString,
+		}
+
+	case reflect.Bool:
+		return &Schema{
+			Type: schemaBoolean,
+		}
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{
+			Type: schemaInteger,
+		}
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		minimum := 0
+
+		return &Schema{
+			Type:    schemaInteger,
+			Minimum: &minimum,
+		}
+
+	case reflect.Float32, reflect.Float64:
+		return &Schema{
+			Type: schemaNumber,
+		}
+	}
+
+	// any value is permitted, eg the flags of a profile, which are
+	// validated against the flags known to the third party program.
+	//
+	return &Schema{}
+}
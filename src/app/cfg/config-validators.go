@@ -3,8 +3,13 @@ package cfg
 import (
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 
+	"github.com/samber/lo"
+	"github.com/snivilised/cobrass/src/clif"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
 	"golang.org/x/exp/maps"
 )
 
@@ -36,6 +41,57 @@ var (
 		"dummy",
 		"magick",
 	}
+
+	// flagValueCheckers check the value of the known third party flags,
+	// returning a description of what is expected when the value is
+	// invalid.
+	flagValueCheckers = map[string]func(value string) (expected string, ok bool){
+		"gaussian-blur": func(value string) (string, bool) {
+			_, err := strconv.ParseFloat(value, 32)
+
+			return "a number", err == nil
+		},
+		"sampling-factor": func(value string) (string, bool) {
+			return common.SamplingFactorEnumInfo.AcceptablePrimes(),
+				common.SamplingFactorEnumInfo.IsValid(value)
+		},
+		"interlace": func(value string) (string, bool) {
+			return common.InterlaceEnumInfo.AcceptablePrimes(),
+				common.InterlaceEnumInfo.IsValid(value)
+		},
+		"strip": func(value string) (string, bool) {
+			_, err := strconv.ParseBool(value)
+
+			return "true or false", err == nil
+		},
+		"quality": func(value string) (string, bool) {
+			const (
+				minQuality = 0
+				maxQuality = 100
+			)
+
+			quality, err := strconv.Atoi(value)
+
+			return "a whole number in range [0, 100]",
+				err == nil && quality >= minQuality && quality <= maxQuality
+		},
+	}
+
+	// nonEmptyTemplateFields are the template fields that always have a
+	// value, so distinguish a path from the folder of the input.
+	nonEmptyTemplateFields = []string{
+		common.TemplateFields.DejaVu,
+		common.TemplateFields.Supplement,
+		common.TemplateFields.Date,
+		common.TemplateFields.Ext,
+	}
+
+	permittedMetricsModes = []string{
+		"", // equivalent to never
+		common.Definitions.Quality.Always,
+		common.Definitions.Quality.Sample,
+		common.Definitions.Quality.Never,
+	}
 )
 
 func validateSuffixes(suffixes []string, from string) error {
@@ -69,3 +125,233 @@ func validateProgramName(name string) error {
 
 	return err
 }
+
+func validateQuality(quality common.QualityConfig) error {
+	if !slices.Contains(permittedMetricsModes, quality.Metrics()) {
+		return fmt.Errorf("invalid quality metrics mode found: '%v'", quality.Metrics())
+	}
+
+	if threshold := quality.MinSSIM(); threshold < 0 || threshold > 1 {
+		return fmt.Errorf("invalid quality min-ssim found: '%v' (must be in range [0, 1])", threshold)
+	}
+
+	return nil
+}
+
+func validateTargetSize(target common.TargetSizeConfig) error {
+	if tolerance := target.Tolerance(); tolerance < 0 || tolerance >= 1 {
+		return fmt.Errorf("invalid target-size tolerance found: '%v' (must be in range [0, 1))", tolerance)
+	}
+
+	if target.MaxAttempts() == 0 {
+		return fmt.Errorf("invalid target-size max-attempts found: '%v' (must be at least 1)",
+			target.MaxAttempts(),
+		)
+	}
+
+	return nil
+}
+
+func validateTargetSizeKey(name string, profile clif.ChangedFlagsMap) error {
+	size, found := profile[common.Definitions.ProfileKeys.TargetSize]
+	if !found {
+		return nil
+	}
+
+	if _, err := common.ParseByteSize(size); err != nil {
+		return fmt.Errorf("invalid %v found in profile: '%v' (%w)",
+			common.Definitions.ProfileKeys.TargetSize, name, err,
+		)
+	}
+
+	return nil
+}
+
+// validateOverrides ensures that every per-extension section of a profile
+// refers to an extension that is handled, ie one of the suffixes or one
+// that appears in the extension map.
+func validateOverrides(name string,
+	spec *common.ProfileSpec,
+	extensions common.ExtensionsConfig,
+	extras []string,
+) error {
+	permitted := lo.Map(strings.Split(extensions.Suffixes(), ","), func(s string, _ int) string {
+		return strings.ToLower(strings.TrimSpace(s))
+	})
+
+	for k, v := range extensions.Map() {
+		permitted = append(permitted, k, v)
+	}
+
+	for extension, override := range spec.Overrides {
+		if !slices.Contains(permitted, extension) {
+			return fmt.Errorf("profile: '%v' contains section for unknown extension: '%v' (permitted: '%v')",
+				name, extension, strings.Join(lo.Uniq(permitted), ","),
+			)
+		}
+
+		if err := validateTargetSizeKey(name, override); err != nil {
+			return err
+		}
+
+		if err := validateFlags(name+"."+extension, override, extras); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateExtends ensures that the profile only extends profiles that
+// exist and that it does not extend itself, either directly or via
+// another profile.
+func validateExtends(name string, specs ProfileSpecsMap) error {
+	const (
+		visiting = iota + 1
+		visited
+	)
+
+	state := make(map[string]int, len(specs))
+
+	var visit func(current string, chain []string) error
+
+	visit = func(current string, chain []string) error {
+		chain = append(chain, current)
+
+		switch state[current] {
+		case visiting:
+			return fmt.Errorf("profile: '%v' has cyclic %v: '%v'",
+				name, extendsKey, strings.Join(chain, " -> "),
+			)
+		case visited:
+			return nil
+		}
+
+		state[current] = visiting
+
+		for _, base := range specs[current].Extends {
+			if _, found := specs[base]; !found {
+				return fmt.Errorf("profile: '%v' extends unknown profile: '%v'", current, base)
+			}
+
+			if err := visit(base, chain); err != nil {
+				return err
+			}
+		}
+
+		state[current] = visited
+
+		return nil
+	}
+
+	return visit(name, nil)
+}
+
+// validateFlags ensures that every key of the profile is either a flag of
+// the third party program known to pixa (in its long or short form), an
+// extra flag allowed by config or a key interpreted by pixa. The values of
+// the known flags are also checked, where their type is known.
+func validateFlags(profile string, flags clif.ChangedFlagsMap, extras []string) error {
+	shorts := lo.Invert(common.ThirdPartyFlags)
+
+	for _, flag := range flags.Keys() {
+		if flag == common.Definitions.ProfileKeys.TargetSize || slices.Contains(extras, flag) {
+			continue
+		}
+
+		long := flag
+
+		if _, known := common.ThirdPartyFlags[flag]; !known {
+			if long, known = shorts[flag]; !known {
+				return locale.NewUnknownProfileFlagError(profile, flag)
+			}
+		}
+
+		if check, found := flagValueCheckers[long]; found {
+			if expected, ok := check(flags[flag]); !ok {
+				return locale.NewInvalidProfileFlagValueError(profile, flag, flags[flag], expected)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateErrorPolicy checks the policy is one of the acceptable values;
+// an absent policy denotes the default, ie continue.
+func validateErrorPolicy(policy string) error {
+	if policy != "" && !common.ErrorPolicyEnumInfo.IsValid(policy) {
+		return fmt.Errorf("invalid error-policy found: '%v' (acceptable: %v)",
+			policy, common.ErrorPolicyEnumInfo.AcceptablePrimes(),
+		)
+	}
+
+	return nil
+}
+
+// validateTemplates checks each of the filing templates, so that a bad
+// template is reported when the config is loaded, rather than when the
+// first path is created from it.
+func validateTemplates(templates common.TemplatesConfig) error {
+	for _, entry := range []struct {
+		spec     common.PathTemplate
+		template string
+	}{
+		{spec: common.PathTemplates.Transfer, template: templates.Transfer()},
+		{spec: common.PathTemplates.Result, template: templates.Result()},
+		{spec: common.PathTemplates.Transparent, template: templates.Transparent()},
+	} {
+		if entry.template == "" {
+			continue
+		}
+
+		if reason := checkTemplate(entry.spec, entry.template); reason != "" {
+			return fmt.Errorf("invalid filing.templates.%v found: '%v' (%v)",
+				entry.spec.Name, entry.template, reason,
+			)
+		}
+	}
+
+	return nil
+}
+
+// checkTemplate returns the reason why the template is not valid, or empty
+// string if it is. The template must start with its root and must not
+// climb out of it. When the path has to be distinct from the folder of
+// the input, there must be a segment that can't evaluate to empty.
+func checkTemplate(spec common.PathTemplate, template string) string {
+	segments := strings.Split(template, "/")
+
+	if segments[0] != spec.Root {
+		return fmt.Sprintf("must start with %v", spec.Root)
+	}
+
+	permitted := append(slices.Clone(spec.Fields), common.GeneralTemplateFields...)
+	distinct := false
+
+	for _, segment := range segments[1:] {
+		if segment == "." || segment == ".." {
+			return fmt.Sprintf("segment '%v' is not permitted", segment)
+		}
+
+		fields := common.TemplateFieldPattern.FindAllString(segment, -1)
+
+		for _, field := range fields {
+			if !slices.Contains(permitted, field) {
+				return fmt.Sprintf("field %v is not permitted", field)
+			}
+		}
+
+		literal := common.TemplateFieldPattern.ReplaceAllString(segment, "")
+
+		if literal != "" || lo.Some(fields, nonEmptyTemplateFields) {
+			distinct = true
+		}
+	}
+
+	if spec.Distinct && !distinct {
+		return "would collide with the input; must contain text or a field that is never empty"
+	}
+
+	return ""
+}
@@ -0,0 +1,139 @@
+package cfg
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"gopkg.in/yaml.v3"
+)
+
+// SetDefaultProfile writes the profile into the config file at the path
+// specified as the default profile. The document is modified in place,
+// rather than being re-created from the master config, so that the
+// comments and ordering chosen by the user are retained.
+func SetDefaultProfile(vfs storage.VirtualFS, path, profile string) error {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	doc, err := readMapping(path, content)
+	if err != nil {
+		return err
+	}
+
+	defaults := ensureMapping(doc.Content[0], "defaults")
+	setScalar(defaults, "profile", profile)
+
+	return writeDocument(vfs, path, doc)
+}
+
+// readMapping parses the content as a yaml document whose root is a
+// mapping.
+func readMapping(name string, content []byte) (*yaml.Node, error) {
+	var (
+		doc yaml.Node
+	)
+
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("config file: '%v' is not valid yaml (%w)", name, err)
+	}
+
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 ||
+		doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file: '%v' does not contain a mapping", name)
+	}
+
+	return &doc, nil
+}
+
+func writeDocument(vfs storage.VirtualFS, path string, doc *yaml.Node) error {
+	var (
+		buf bytes.Buffer
+	)
+
+	const (
+		indent = 2
+	)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(indent)
+
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return vfs.WriteFile(path, buf.Bytes(), common.Permissions.Write)
+}
+
+// ensureMapping returns the mapping node stored under key within parent,
+// creating it if it does not yet exist.
+func ensureMapping(parent *yaml.Node, key string) *yaml.Node {
+	if value := lookup(parent, key); value != nil && value.Kind == yaml.MappingNode {
+		return value
+	}
+
+	value := &yaml.Node{
+		Kind: yaml.MappingNode,
+		Tag:  "!!map",
+	}
+	assign(parent, key, value)
+
+	return value
+}
+
+func setScalar(parent *yaml.Node, key, value string) {
+	assign(parent, key, &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Tag:   "!!str",
+		Value: value,
+	})
+}
+
+func lookup(parent *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			return parent.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// remove deletes the key from parent, returning whether it was present.
+func remove(parent *yaml.Node, key string) bool {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			parent.Content = append(parent.Content[:i], parent.Content[i+2:]...)
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func assign(parent *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == key {
+			parent.Content[i+1] = value
+
+			return
+		}
+	}
+
+	parent.Content = append(parent.Content,
+		&yaml.Node{
+			Kind:  yaml.ScalarNode,
+			Tag:   "!!str",
+			Value: key,
+		},
+		value,
+	)
+}
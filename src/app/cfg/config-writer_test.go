@@ -0,0 +1,58 @@
+package cfg_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+var _ = Describe("ConfigWriter", func() {
+	var (
+		vfs  storage.VirtualFS
+		path string
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		path = filepath.Join(GinkgoT().TempDir(), "pixa.yml")
+	})
+
+	Context("given: config without defaults", func() {
+		It("🧪 should: add default profile and retain comments", func() {
+			content := "# my profiles\nprofiles:\n  sf:\n    strip: true\n"
+			Expect(vfs.WriteFile(path, []byte(content), common.Permissions.Write)).To(Succeed())
+
+			Expect(cfg.SetDefaultProfile(vfs, path, "sf")).To(Succeed())
+
+			written, err := vfs.ReadFile(path)
+			Expect(err).To(Succeed())
+			Expect(string(written)).To(ContainSubstring("# my profiles"))
+			Expect(string(written)).To(ContainSubstring("defaults:\n  profile: sf"))
+		})
+	})
+
+	Context("given: config with existing default", func() {
+		It("🧪 should: replace default profile", func() {
+			content := "defaults:\n  profile: blur\nsampler:\n  files: 2\n"
+			Expect(vfs.WriteFile(path, []byte(content), common.Permissions.Write)).To(Succeed())
+
+			Expect(cfg.SetDefaultProfile(vfs, path, "adaptive")).To(Succeed())
+
+			written, err := vfs.ReadFile(path)
+			Expect(err).To(Succeed())
+			Expect(string(written)).To(Equal("defaults:\n  profile: adaptive\nsampler:\n  files: 2\n"))
+		})
+	})
+
+	Context("given: config that is not a mapping", func() {
+		It("🧪 should: return error", func() {
+			Expect(vfs.WriteFile(path, []byte("- foo\n"), common.Permissions.Write)).To(Succeed())
+			Expect(cfg.SetDefaultProfile(vfs, path, "sf")).NotTo(Succeed())
+		})
+	})
+})
@@ -0,0 +1,9 @@
+package cfg
+
+// EnvKeys returns the names of the environment variables of the keys of
+// the schema
+func EnvKeys(schema *Schema) ([]string, error) {
+	keys, err := envKeys(schema)
+
+	return sortedKeys(keys), err
+}
@@ -2,6 +2,7 @@ package cfg
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -17,20 +18,11 @@ import (
 
 type (
 	ProfilesFlagOptionAsAnyPair = map[string]any
+	ProfilesRawMap              map[string]ProfilesFlagOptionAsAnyPair
 	ProfilesConfigMap           map[string]clif.ChangedFlagsMap
 	SchemesConfigMap            map[string][]string
 )
 
-type MsProfilesConfig struct {
-	Profiles ProfilesConfigMap
-}
-
-func (c MsProfilesConfig) Profile(name string) (clif.ChangedFlagsMap, bool) {
-	profile, found := c.Profiles[name]
-
-	return profile, found
-}
-
 type MsSchemeConfig struct {
 	ProfilesData []string `mapstructure:"profiles"`
 }
@@ -66,6 +58,13 @@ func (c MsSchemesConfig) Validate(name string, profiles common.ProfilesConfig) e
 	return nil
 }
 
+func (c MsSchemesConfig) Names() []string {
+	names := lo.Keys(c)
+	slices.Sort(names)
+
+	return names
+}
+
 func (c MsSchemesConfig) Scheme(name string) (common.SchemeConfig, bool) {
 	config, found := c[name]
 
@@ -85,11 +84,21 @@ func (c *MsSamplerConfig) NoFolders() uint {
 	return c.Folders
 }
 
+type MsDefaultsConfig struct {
+	ProfileName string `mapstructure:"profile"`
+}
+
+func (c *MsDefaultsConfig) Profile() string {
+	return c.ProfileName
+}
+
 type MsLabelsConfig struct {
 	Adhoc      string `mapstructure:"adhoc"`
 	Journal    string `mapstructure:"journal-suffix"`
 	Legacy     string `mapstructure:"legacy"`
 	Trash      string `mapstructure:"trash"`
+	Failed     string `mapstructure:"failed"`
+	DejaVu     string `mapstructure:"deja-vu"`
 	Fake       string `mapstructure:"fake"`
 	Supplement string `mapstructure:"supplement"`
 	Sample     string `mapstructure:"sample"`
@@ -114,9 +123,10 @@ func (c *MsExtensionsConfig) Map() map[string]string {
 }
 
 type MsExecutableConfig struct {
-	ProgramName      string `mapstructure:"program-name"`
-	Timeout          string `mapstructure:"timeout"`
-	NoProgramRetries uint   `mapstructure:"no-retries"`
+	ProgramName      string   `mapstructure:"program-name"`
+	Timeout          string   `mapstructure:"timeout"`
+	NoProgramRetries uint     `mapstructure:"no-retries"`
+	Extras           []string `mapstructure:"extra-flags"`
 }
 
 func (c *MsExecutableConfig) Symbol() string {
@@ -131,15 +141,52 @@ func (c *MsExecutableConfig) NoRetries() uint {
 	return c.NoProgramRetries
 }
 
+func (c *MsExecutableConfig) ExtraFlags() []string {
+	return c.Extras
+}
+
+type MsQualityConfig struct {
+	MetricsMode string  `mapstructure:"metrics"`
+	Threshold   float64 `mapstructure:"min-ssim"`
+}
+
+func (c *MsQualityConfig) Metrics() string {
+	return c.MetricsMode
+}
+
+func (c *MsQualityConfig) MinSSIM() float64 {
+	return c.Threshold
+}
+
+type MsTargetSizeConfig struct {
+	Within   float64 `mapstructure:"tolerance"`
+	Attempts uint    `mapstructure:"max-attempts"`
+}
+
+func (c *MsTargetSizeConfig) Tolerance() float64 {
+	return c.Within
+}
+
+func (c *MsTargetSizeConfig) MaxAttempts() uint {
+	return c.Attempts
+}
+
 type MsAdvancedConfig struct {
-	Abort         bool               `mapstructure:"abort-on-error"`
+	Policy        string             `mapstructure:"error-policy"`
+	MaxFailed     uint               `mapstructure:"max-failures"`
 	LabelsCFG     MsLabelsConfig     `mapstructure:"labels"`
 	ExtensionsCFG MsExtensionsConfig `mapstructure:"extensions"`
 	ExecutableCFG MsExecutableConfig `mapstructure:"executable"`
+	QualityCFG    MsQualityConfig    `mapstructure:"quality"`
+	TargetSizeCFG MsTargetSizeConfig `mapstructure:"target-size"`
 }
 
-func (c *MsAdvancedConfig) AbortOnError() bool {
-	return c.Abort
+func (c *MsAdvancedConfig) ErrorPolicy() string {
+	return c.Policy
+}
+
+func (c *MsAdvancedConfig) MaxFailures() uint {
+	return c.MaxFailed
 }
 
 func (c *MsAdvancedConfig) AdhocLabel() string {
@@ -158,6 +205,14 @@ func (c *MsAdvancedConfig) TrashLabel() string {
 	return c.LabelsCFG.Trash
 }
 
+func (c *MsAdvancedConfig) FailedLabel() string {
+	return c.LabelsCFG.Failed
+}
+
+func (c *MsAdvancedConfig) DejaVuLabel() string {
+	return c.LabelsCFG.DejaVu
+}
+
 func (c *MsAdvancedConfig) FakeLabel() string {
 	return c.LabelsCFG.Fake
 }
@@ -178,6 +233,40 @@ func (c *MsAdvancedConfig) Executable() common.ExecutableConfig {
 	return &c.ExecutableCFG
 }
 
+func (c *MsAdvancedConfig) Quality() common.QualityConfig {
+	return &c.QualityCFG
+}
+
+func (c *MsAdvancedConfig) TargetSize() common.TargetSizeConfig {
+	return &c.TargetSizeCFG
+}
+
+type MsTemplatesConfig struct {
+	TransferTempl    string `mapstructure:"transfer"`
+	ResultTempl      string `mapstructure:"result"`
+	TransparentTempl string `mapstructure:"transparent"`
+}
+
+func (c *MsTemplatesConfig) Transfer() string {
+	return c.TransferTempl
+}
+
+func (c *MsTemplatesConfig) Result() string {
+	return c.ResultTempl
+}
+
+func (c *MsTemplatesConfig) Transparent() string {
+	return c.TransparentTempl
+}
+
+type MsFilingConfig struct {
+	TemplatesCFG MsTemplatesConfig `mapstructure:"templates"`
+}
+
+func (c *MsFilingConfig) Templates() common.TemplatesConfig {
+	return &c.TemplatesCFG
+}
+
 type MsLoggingConfig struct {
 	LogPath    string `mapstructure:"log-path"`
 	MaxSize    uint   `mapstructure:"max-size-in-mb"`
@@ -212,11 +301,13 @@ func (c *MsLoggingConfig) TimeFormat() string {
 }
 
 type MsMasterConfig struct {
-	Profiles    ProfilesConfigMap   `mapstructure:"profiles"`
+	Profiles    ProfilesRawMap      `mapstructure:"profiles"`
 	Schemes     SchemesConfigMap    `mapstructure:"schemes"`
 	Sampler     MsSamplerConfig     `mapstructure:"sampler"`
+	Defaults    MsDefaultsConfig    `mapstructure:"defaults"`
 	Interaction MsInteractionConfig `mapstructure:"interaction"`
 	Advanced    MsAdvancedConfig    `mapstructure:"advanced"`
+	Filing      MsFilingConfig      `mapstructure:"filing"`
 	Logging     MsLoggingConfig     `mapstructure:"logging"`
 }
 
@@ -225,6 +316,20 @@ func (c *MsMasterConfig) Read(vc configuration.ViperConfig) (*common.Configs, er
 		return nil, err
 	}
 
+	return c.build()
+}
+
+// build creates the configs from the decoded config and validates them.
+func (c *MsMasterConfig) build() (*common.Configs, error) {
+	specs, err := splitProfiles(c.Profiles)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &MsProfilesConfig{
+		Specs:    specs,
+		advanced: &c.Advanced,
+	}
 	schemes := make(MsSchemesConfig)
 
 	for k, v := range c.Schemes {
@@ -234,20 +339,26 @@ func (c *MsMasterConfig) Read(vc configuration.ViperConfig) (*common.Configs, er
 	}
 
 	configs := &common.Configs{
-		Profiles: MsProfilesConfig{
-			Profiles: c.Profiles,
-		},
+		Profiles:    ms,
 		Schemes:     schemes,
 		Sampler:     &c.Sampler,
+		Defaults:    &c.Defaults,
 		Interaction: &c.Interaction,
 		Advanced:    &c.Advanced,
+		Filing:      &c.Filing,
 		Logging:     &c.Logging,
 	}
 
-	return configs, c.validate(configs)
+	if err := c.validate(configs, ms); err != nil {
+		return configs, err
+	}
+
+	ms.resolve()
+
+	return configs, nil
 }
 
-func (c *MsMasterConfig) validate(configs *common.Configs) error {
+func (c *MsMasterConfig) validate(configs *common.Configs, ms *MsProfilesConfig) error {
 	extensions := configs.Advanced.Extensions()
 	mappings := extensions.Map()
 	keys := lo.Keys(mappings)
@@ -260,6 +371,22 @@ func (c *MsMasterConfig) validate(configs *common.Configs) error {
 	// placed to perform that validation as it has access to the
 	// inputs and can act accordingly.
 
+	// defaults
+	//
+	if name := configs.Defaults.Profile(); name != "" {
+		if _, found := ms.Declared(name); !found {
+			return fmt.Errorf("defaults.profile: '%v' not found in config", name)
+		}
+	}
+
+	// profiles
+	//
+	for _, name := range ms.Names() {
+		if err := ms.Validate(name); err != nil {
+			return err
+		}
+	}
+
 	// extensions
 	//
 	if err := validateSuffixes(keys, "extensions.map/keys"); err != nil {
@@ -279,6 +406,30 @@ func (c *MsMasterConfig) validate(configs *common.Configs) error {
 		return err
 	}
 
+	// quality
+	//
+	if err := validateQuality(configs.Advanced.Quality()); err != nil {
+		return err
+	}
+
+	// target-size
+	//
+	if err := validateTargetSize(configs.Advanced.TargetSize()); err != nil {
+		return err
+	}
+
+	// error-policy
+	//
+	if err := validateErrorPolicy(configs.Advanced.ErrorPolicy()); err != nil {
+		return err
+	}
+
+	// filing
+	//
+	if err := validateTemplates(configs.Filing.Templates()); err != nil {
+		return err
+	}
+
 	// executable
 	//
 	executable := configs.Advanced.Executable()
@@ -0,0 +1,322 @@
+package cfg_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+	"github.com/samber/lo"
+	"github.com/spf13/viper"
+
+	"github.com/snivilised/cobrass/src/assistant/configuration"
+	"github.com/snivilised/cobrass/src/clif"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/internal/helpers"
+)
+
+func readMasterConfig(content string) (*common.Configs, error) {
+	viper.Reset()
+	viper.SetConfigType("yaml")
+
+	if err := viper.ReadConfig(strings.NewReader(content)); err != nil {
+		return nil, err
+	}
+
+	mc := &cfg.MsMasterConfig{}
+
+	return mc.Read(&configuration.GlobalViperConfig{})
+}
+
+const sectionedConfig = `
+profiles:
+  sf:
+    strip: true
+    sampling-factor: "4:2:0"
+  adaptive:
+    default:
+      strip: true
+      interlace: "plane"
+      adaptive-resize: "60"
+    png:
+      interlace: "none"
+    jpg:
+      quality: 80
+advanced:
+  extensions:
+    suffixes-csv: "jpg,jpeg,png"
+    map:
+      jpeg: jpg
+  executable:
+    program-name: "magick"
+    extra-flags: ["adaptive-resize"]
+  target-size:
+    tolerance: 0.05
+    max-attempts: 8
+`
+
+var _ = Describe("MsMasterConfig", func() {
+	BeforeEach(func() {
+		xi18n.ResetTx()
+		Expect(helpers.UseI18n(helpers.Path(helpers.Repo(""), "test/data/l10n"))).To(Succeed())
+	})
+
+	AfterEach(func() {
+		viper.Reset()
+	})
+
+	Context("given: profile with per extension sections", func() {
+		var configs *common.Configs
+
+		BeforeEach(func() {
+			var err error
+
+			configs, err = readMasterConfig(sectionedConfig)
+			Expect(err).Error().To(BeNil())
+		})
+
+		It("🧪 should: return default flags", func() {
+			profile, found := configs.Profiles.Profile("adaptive")
+
+			Expect(found).To(BeTrue())
+			Expect(profile).To(Equal(clif.ChangedFlagsMap{
+				"strip":           "true",
+				"interlace":       "plane",
+				"adaptive-resize": "60",
+			}))
+		})
+
+		It("🧪 should: override default flags for extension", func() {
+			profile, found := configs.Profiles.ProfileFor("adaptive", "png")
+
+			Expect(found).To(BeTrue())
+			Expect(profile).To(Equal(clif.ChangedFlagsMap{
+				"strip":           "true",
+				"interlace":       "none",
+				"adaptive-resize": "60",
+			}))
+		})
+
+		It("🧪 should: override default flags for mapped extension", func() {
+			profile, _ := configs.Profiles.ProfileFor("adaptive", "jpeg", "jpg")
+
+			Expect(profile).To(HaveKeyWithValue("quality", "80"))
+			Expect(profile).To(HaveKeyWithValue("interlace", "plane"))
+		})
+
+		It("🧪 should: return default flags for extension without section", func() {
+			profile, _ := configs.Profiles.ProfileFor("adaptive", "gif")
+
+			Expect(profile).To(HaveKeyWithValue("interlace", "plane"))
+			Expect(profile).NotTo(HaveKey("quality"))
+		})
+
+		It("🧪 should: return profile names in order", func() {
+			Expect(configs.Profiles.Names()).To(Equal([]string{"adaptive", "sf"}))
+		})
+
+		It("🧪 should: validate profile", func() {
+			Expect(configs.Profiles.Validate("adaptive")).To(Succeed())
+			Expect(configs.Profiles.Validate("missing")).NotTo(Succeed())
+		})
+
+		It("🧪 should: return flat profile for any extension", func() {
+			profile, found := configs.Profiles.ProfileFor("sf", "png")
+
+			Expect(found).To(BeTrue())
+			Expect(profile).To(Equal(clif.ChangedFlagsMap{
+				"strip":           "true",
+				"sampling-factor": "4:2:0",
+			}))
+		})
+	})
+
+	Context("given: profiles that extend other profiles", func() {
+		const content = `
+profiles:
+  base:
+    strip: true
+    interlace: "plane"
+  web:
+    quality: 85
+    png:
+      interlace: "none"
+  blur:
+    extends: [base, web]
+    gaussian-blur: "0.05"
+  soft:
+    extends: blur
+    interlace: "line"
+advanced:
+  extensions:
+    suffixes-csv: "jpg,jpeg,png"
+  executable:
+    program-name: "magick"
+    extra-flags: ["adaptive-resize"]
+  target-size:
+    tolerance: 0.05
+    max-attempts: 8
+`
+		var configs *common.Configs
+
+		BeforeEach(func() {
+			var err error
+
+			configs, err = readMasterConfig(content)
+			Expect(err).Error().To(BeNil())
+		})
+
+		It("🧪 should: merge bases in order", func() {
+			profile, found := configs.Profiles.Profile("blur")
+
+			Expect(found).To(BeTrue())
+			Expect(profile).To(Equal(clif.ChangedFlagsMap{
+				"strip":         "true",
+				"interlace":     "plane",
+				"quality":       "85",
+				"gaussian-blur": "0.05",
+			}))
+		})
+
+		It("🧪 should: inherit extension sections of bases", func() {
+			profile, _ := configs.Profiles.ProfileFor("blur", "png")
+
+			Expect(profile).To(HaveKeyWithValue("interlace", "none"))
+		})
+
+		It("🧪 should: let derived flags take precedence over bases", func() {
+			profile, _ := configs.Profiles.ProfileFor("soft", "png")
+
+			Expect(profile).To(HaveKeyWithValue("interlace", "line"))
+			Expect(profile).To(HaveKeyWithValue("gaussian-blur", "0.05"))
+		})
+
+		It("🧪 should: retain declared profile", func() {
+			spec, found := configs.Profiles.Declared("soft")
+
+			Expect(found).To(BeTrue())
+			Expect(spec.Extends).To(Equal([]string{"blur"}))
+			Expect(spec.Flags).To(Equal(clif.ChangedFlagsMap{
+				"interlace": "line",
+			}))
+		})
+	})
+
+	Context("given: profiles with cyclic extends", func() {
+		It("🧪 should: return error", func() {
+			content := strings.Replace(sectionedConfig, `  sf:
+    strip: true`, `  sf:
+    extends: [adaptive]
+    strip: true`, 1)
+			content = strings.Replace(content, `  adaptive:
+    default:`, `  adaptive:
+    extends: sf
+    default:`, 1)
+			_, err := readMasterConfig(content)
+
+			Expect(err).To(MatchError(ContainSubstring("cyclic extends: 'adaptive -> sf -> adaptive'")))
+		})
+	})
+
+	Context("given: profile that extends unknown profile", func() {
+		It("🧪 should: return error", func() {
+			content := strings.Replace(sectionedConfig, `  sf:
+    strip: true`, `  sf:
+    extends: [missing]
+    strip: true`, 1)
+			_, err := readMasterConfig(content)
+
+			Expect(err).To(MatchError(ContainSubstring("extends unknown profile: 'missing'")))
+		})
+	})
+
+	Context("given: profile with unknown flag", func() {
+		It("🧪 should: return error", func() {
+			content := strings.Replace(sectionedConfig, "    sampling-factor:", "    sampling-facter:", 1)
+			_, err := readMasterConfig(content)
+
+			Expect(err).To(MatchError(ContainSubstring("profile: 'sf' contains unknown flag: 'sampling-facter'")))
+		})
+	})
+
+	Context("given: profile with short form of known flag", func() {
+		It("🧪 should: not return error", func() {
+			content := strings.Replace(sectionedConfig, "    sampling-factor:", "    f:", 1)
+			_, err := readMasterConfig(content)
+
+			Expect(err).Error().To(BeNil())
+		})
+	})
+
+	DescribeTable("given: profile with invalid flag value",
+		func(from, to, expected string) {
+			content := strings.Replace(sectionedConfig, from, to, 1)
+			_, err := readMasterConfig(content)
+
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+		func(from, to, expected string) string {
+			return fmt.Sprintf("🧪 ===> given: '%v', should: return error", to)
+		},
+		Entry(nil, `sampling-factor: "4:2:0"`, `sampling-factor: "4:4:1"`,
+			"profile: 'sf' contains flag: 'sampling-factor' with invalid value: '4:4:1'",
+		),
+		Entry(nil, `interlace: "none"`, `interlace: "zig"`,
+			"profile: 'adaptive.png' contains flag: 'interlace' with invalid value: 'zig'",
+		),
+		Entry(nil, `quality: 80`, `quality: 180`,
+			"profile: 'adaptive.jpg' contains flag: 'quality' with invalid value: '180'",
+		),
+		Entry(nil, `strip: true
+    sampling-factor`, `strip: true
+    gaussian-blur: heavy
+    sampling-factor`,
+			"profile: 'sf' contains flag: 'gaussian-blur' with invalid value: 'heavy'",
+		),
+	)
+
+	DescribeTable("given: filing template",
+		func(template, expected string) {
+			content := sectionedConfig + fmt.Sprintf(`filing:
+  templates:
+    %v
+`, template)
+			_, err := readMasterConfig(content)
+
+			if expected == "" {
+				Expect(err).Error().To(BeNil())
+
+				return
+			}
+
+			Expect(err).To(MatchError(ContainSubstring(expected)))
+		},
+		func(template, expected string) string {
+			return fmt.Sprintf("🧪 ===> given: '%v', should: %v", template,
+				lo.Ternary(expected == "", "be valid", "return error"),
+			)
+		},
+		Entry(nil, `result: "${{OUTPUT-ROOT}}/web/${{DATE}}/${{EXT}}"`, ""),
+		Entry(nil, `result: "${{OUTPUT-ROOT}}/${{SCHEME}}-${{PROFILE}}/${{SUPPLEMENT}}"`, ""),
+		Entry(nil, `transfer: "${{TRANSFER-DESTINATION}}/${{DEJA-VU}}/${{ITEM-SUB-PATH}}"`, ""),
+		Entry(nil, `transparent: "${{OUTPUT-ROOT}}"`, ""),
+		Entry(nil, `result: "/var/www/${{SUPPLEMENT}}"`,
+			"invalid filing.templates.result found: '/var/www/${{SUPPLEMENT}}' (must start with ${{OUTPUT-ROOT}})",
+		),
+		Entry(nil, `result: "${{OUTPUT-ROOT}}/../${{SUPPLEMENT}}"`, "segment '..' is not permitted"),
+		Entry(nil, `result: "${{OUTPUT-ROOT}}/${{DEJA-VU}}"`, "field ${{DEJA-VU}} is not permitted"),
+		Entry(nil, `result: "${{OUTPUT-ROOT}}/${{ITEM-SUB-PATH}}/${{PROFILE}}"`, "would collide with the input"),
+		Entry(nil, `transfer: "${{TRANSFER-DESTINATION}}/${{ITEM-SUB-PATH}}"`, "would collide with the input"),
+	)
+
+	Context("given: profile with section for unknown extension", func() {
+		It("🧪 should: return error", func() {
+			content := strings.Replace(sectionedConfig, "    png:", "    tiff:", 1)
+			_, err := readMasterConfig(content)
+
+			Expect(err).To(MatchError(ContainSubstring("unknown extension: 'tiff'")))
+		})
+	})
+})
@@ -0,0 +1,282 @@
+package cfg
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/snivilised/cobrass/src/clif"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+type (
+	// ProfileOverridesMap maps profile name to extension to the flags that
+	// override the default flags of that profile for the extension.
+	ProfileOverridesMap map[string]map[string]clif.ChangedFlagsMap
+
+	// ProfileSpecsMap maps profile name to the profile as declared in config.
+	ProfileSpecsMap map[string]*common.ProfileSpec
+)
+
+const (
+	defaultSection = "default"
+	extendsKey     = "extends"
+)
+
+// MsProfilesConfig contains the profiles as declared (Specs) and as
+// resolved (Profiles and Overrides), ie with the profiles they extend
+// merged in. Only the declared profiles are populated until the config
+// has been validated.
+type MsProfilesConfig struct {
+	Profiles  ProfilesConfigMap
+	Overrides ProfileOverridesMap
+	Specs     ProfileSpecsMap
+
+	advanced common.AdvancedConfig
+}
+
+func (c *MsProfilesConfig) Names() []string {
+	names := lo.Keys(c.Specs)
+	slices.Sort(names)
+
+	return names
+}
+
+// Validate checks the profile as declared; the profiles it extends, its
+// keys and the values of its flags.
+func (c *MsProfilesConfig) Validate(name string) error {
+	spec, found := c.Specs[name]
+	if !found {
+		return fmt.Errorf("profile: '%v' not found in config", name)
+	}
+
+	if err := validateExtends(name, c.Specs); err != nil {
+		return err
+	}
+
+	if err := validateTargetSizeKey(name, spec.Flags); err != nil {
+		return err
+	}
+
+	extras := c.advanced.Executable().ExtraFlags()
+
+	if err := validateFlags(name, spec.Flags, extras); err != nil {
+		return err
+	}
+
+	return validateOverrides(name, spec, c.advanced.Extensions(), extras)
+}
+
+func (c *MsProfilesConfig) Profile(name string) (clif.ChangedFlagsMap, bool) {
+	profile, found := c.Profiles[name]
+
+	return profile, found
+}
+
+// ProfileFor returns the flags of the profile that apply to the extension.
+// The extensions are candidates tried in order (eg the extension of the
+// item, followed by its mapped extension); the first one that has an
+// override section is merged over the default flags of the profile.
+func (c *MsProfilesConfig) ProfileFor(name string, extensions ...string) (clif.ChangedFlagsMap, bool) {
+	profile, found := c.Profiles[name]
+	if !found {
+		return profile, found
+	}
+
+	for _, extension := range extensions {
+		if override, found := c.Overrides[name][extension]; found {
+			merged := maps.Clone(profile)
+			maps.Copy(merged, override)
+
+			return merged, true
+		}
+	}
+
+	return profile, true
+}
+
+func (c *MsProfilesConfig) Declared(name string) (*common.ProfileSpec, bool) {
+	spec, found := c.Specs[name]
+
+	return spec, found
+}
+
+func (c *MsProfilesConfig) Resolved(name string) (*common.ProfileSpec, bool) {
+	profile, found := c.Profiles[name]
+	if !found {
+		return nil, false
+	}
+
+	return &common.ProfileSpec{
+		Flags:     profile,
+		Overrides: c.Overrides[name],
+	}, true
+}
+
+// resolve merges the profiles that each profile extends into it. The
+// extends chains must already have been validated, so that they contain
+// no unknown profiles or cycles.
+func (c *MsProfilesConfig) resolve() {
+	c.Profiles = make(ProfilesConfigMap, len(c.Specs))
+	c.Overrides = make(ProfileOverridesMap)
+
+	for name := range c.Specs {
+		c.resolveProfile(name)
+	}
+}
+
+// resolveProfile merges the profiles extended by the named profile in
+// the order declared, so that a later base takes precedence over an
+// earlier one and the profile's own flags take precedence over all of
+// them. The override of an extension is resolved to the complete set of
+// flags for that extension, which is the result of applying the same
+// merge to the flags each base uses for that extension.
+func (c *MsProfilesConfig) resolveProfile(name string) (clif.ChangedFlagsMap, map[string]clif.ChangedFlagsMap) {
+	if flags, found := c.Profiles[name]; found {
+		return flags, c.Overrides[name]
+	}
+
+	spec := c.Specs[name]
+	flags := make(clif.ChangedFlagsMap)
+	bases := make([]resolvedBase, 0, len(spec.Extends))
+	extensions := lo.Keys(spec.Overrides)
+
+	for _, base := range spec.Extends {
+		baseFlags, baseOverrides := c.resolveProfile(base)
+		maps.Copy(flags, baseFlags)
+		bases = append(bases, resolvedBase{flags: baseFlags, overrides: baseOverrides})
+		extensions = append(extensions, lo.Keys(baseOverrides)...)
+	}
+
+	maps.Copy(flags, spec.Flags)
+
+	c.Profiles[name] = flags
+
+	if len(extensions) == 0 {
+		return flags, nil
+	}
+
+	overrides := make(map[string]clif.ChangedFlagsMap)
+
+	for _, extension := range extensions {
+		if _, found := overrides[extension]; found {
+			continue
+		}
+
+		override := make(clif.ChangedFlagsMap)
+
+		for _, base := range bases {
+			if baseOverride, found := base.overrides[extension]; found {
+				maps.Copy(override, baseOverride)
+			} else {
+				maps.Copy(override, base.flags)
+			}
+		}
+
+		maps.Copy(override, spec.Flags)
+		maps.Copy(override, spec.Overrides[extension])
+		overrides[extension] = override
+	}
+
+	c.Overrides[name] = overrides
+
+	return flags, overrides
+}
+
+type resolvedBase struct {
+	flags     clif.ChangedFlagsMap
+	overrides map[string]clif.ChangedFlagsMap
+}
+
+// splitProfiles separates the default flags of each profile from its
+// per-extension override sections and the profiles it extends. A profile
+// may be defined flat (ie just flags), or in sections, where the "default"
+// section contains the flags that apply to all extensions and every other
+// section is named after an extension whose flags override the defaults.
+// Flat flags may also appear alongside sections, in which case they are
+// also defaults.
+func splitProfiles(raw ProfilesRawMap) (ProfileSpecsMap, error) {
+	specs := make(ProfileSpecsMap, len(raw))
+
+	for name, entries := range raw {
+		spec := &common.ProfileSpec{
+			Flags: make(clif.ChangedFlagsMap),
+		}
+
+		for key, value := range entries {
+			if key == extendsKey {
+				extends, err := splitExtends(name, value)
+				if err != nil {
+					return nil, err
+				}
+
+				spec.Extends = extends
+
+				continue
+			}
+
+			section, isSection := value.(map[string]any)
+
+			switch {
+			case !isSection:
+				spec.Flags[key] = fmt.Sprint(value)
+
+			case key == defaultSection:
+				for flag, v := range section {
+					spec.Flags[flag] = fmt.Sprint(v)
+				}
+
+			default:
+				extension := strings.ToLower(strings.TrimPrefix(key, "."))
+				override := make(clif.ChangedFlagsMap, len(section))
+
+				for flag, v := range section {
+					if _, nested := v.(map[string]any); nested {
+						return nil, fmt.Errorf(
+							"profile: '%v', section: '%v' contains nested section: '%v'",
+							name, key, flag,
+						)
+					}
+
+					override[flag] = fmt.Sprint(v)
+				}
+
+				if spec.Overrides == nil {
+					spec.Overrides = make(map[string]clif.ChangedFlagsMap)
+				}
+
+				spec.Overrides[extension] = override
+			}
+		}
+
+		specs[name] = spec
+	}
+
+	return specs, nil
+}
+
+// splitExtends accepts either a single profile name or a list of them.
+func splitExtends(name string, value any) ([]string, error) {
+	switch extends := value.(type) {
+	case string:
+		return []string{extends}, nil
+
+	case []any:
+		result := make([]string, 0, len(extends))
+
+		for _, base := range extends {
+			s, ok := base.(string)
+			if !ok {
+				return nil, fmt.Errorf("profile: '%v' extends invalid profile name: '%v'", name, base)
+			}
+
+			result = append(result, s)
+		}
+
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("profile: '%v' contains invalid %v: '%v'", name, extendsKey, value)
+}
@@ -0,0 +1,85 @@
+package cfg
+
+import (
+	"io"
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/snivilised/cobrass/src/clif"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"gopkg.in/yaml.v3"
+)
+
+// PrintProfile writes the profile to w in the same form as it would be
+// declared in config; the profiles it extends, followed by the default
+// flags and then a section for each extension, all in a stable order.
+func PrintProfile(w io.Writer, name string, spec *common.ProfileSpec) error {
+	body := &yaml.Node{
+		Kind: yaml.MappingNode,
+	}
+
+	if len(spec.Extends) > 0 {
+		extends := &yaml.Node{
+			Kind:  yaml.SequenceNode,
+			Style: yaml.FlowStyle,
+		}
+
+		for _, base := range spec.Extends {
+			extends.Content = append(extends.Content, scalar(base))
+		}
+
+		assign(body, extendsKey, extends)
+	}
+
+	if len(spec.Overrides) == 0 {
+		body.Content = append(body.Content, flagsNode(spec.Flags).Content...)
+	} else {
+		assign(body, defaultSection, flagsNode(spec.Flags))
+
+		extensions := lo.Keys(spec.Overrides)
+		slices.Sort(extensions)
+
+		for _, extension := range extensions {
+			assign(body, extension, flagsNode(spec.Overrides[extension]))
+		}
+	}
+
+	profile := &yaml.Node{
+		Kind: yaml.MappingNode,
+	}
+	assign(profile, name, body)
+
+	const (
+		indent = 2
+	)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(indent)
+
+	if err := encoder.Encode(profile); err != nil {
+		return err
+	}
+
+	return encoder.Close()
+}
+
+func flagsNode(flags clif.ChangedFlagsMap) *yaml.Node {
+	node := &yaml.Node{
+		Kind: yaml.MappingNode,
+	}
+
+	for _, flag := range flags.Keys() {
+		assign(node, flag, scalar(flags[flag]))
+	}
+
+	return node
+}
+
+// scalar creates an untagged node, so that the encoder only quotes the
+// value when required.
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{
+		Kind:  yaml.ScalarNode,
+		Value: value,
+	}
+}
@@ -0,0 +1,66 @@
+package command
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+
+	"github.com/snivilised/cobrass/src/assistant"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/snivilised/pixa/src/app/proxy"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+func (b *Bootstrap) buildApplyCommand(container *assistant.CobraContainer) *cobra.Command {
+	applyCommand := &cobra.Command{
+		Use: "apply",
+		Short: locale.LeadsWith(
+			"apply",
+			xi18n.Text(locale.ApplyCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.ApplyLongDefinitionTemplData{}),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(_ *cobra.Command, args []string) error {
+			b.Logger.Info(
+				fmt.Sprintf("%v %v running apply",
+					common.Definitions.Pixa.AppName, common.Definitions.Pixa.Emoji,
+				),
+				slog.String("args", strings.Join(args, "/")),
+			)
+
+			return proxy.EnterApply(&proxy.ApplyParams{
+				Path:    utils.ResolvePath(args[0]),
+				Program: b.Configs.Advanced.Executable().Symbol(),
+				Workers: b.workers(),
+				Logger:  b.Logger,
+				Vfs:     b.Vfs,
+			})
+		},
+	}
+
+	container.MustRegisterRootedCommand(applyCommand)
+
+	return applyCommand
+}
+
+// workers returns the number of workers requested by the worker pool
+// family; the absence of either flag denotes sequential processing.
+func (b *Bootstrap) workers() int {
+	wpf := b.getRootInputs().WorkerPoolFam.Native
+
+	switch {
+	case wpf.CPU:
+		return runtime.NumCPU()
+
+	case wpf.NoWorkers > 0:
+		return wpf.NoWorkers
+	}
+
+	return 1
+}
@@ -0,0 +1,24 @@
+package command_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var _ = Describe("ApplyCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	When("plan file does not exist", func() {
+		It("🧪 should: return invalid plan error", func() {
+			_, err := fixture.execute(
+				common.Definitions.Commands.Apply, filepath.Join(fixture.root, "missing-plan.json"),
+			)
+			Expect(err).To(BeAssignableToTypeOf(locale.InvalidPlanError{}))
+		})
+	})
+})
@@ -3,12 +3,16 @@ package command
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
 
 	"github.com/cubiest/jibberjabber"
 	"github.com/samber/lo"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"golang.org/x/text/language"
 
@@ -64,11 +68,19 @@ type Bootstrap struct {
 	Container     *assistant.CobraContainer
 	OptionsInfo   ConfigureOptionsInfo
 	Configs       *common.Configs
+	Layers        cfg.ConfigLayers
 	Vfs           storage.VirtualFS
 	Logger        *slog.Logger
 	Presentation  common.PresentationOptions
 	Observers     common.Observers
 	Notifications common.LifecycleNotifications
+
+	// err is the error that occurred during initialisation. Instead of
+	// terminating, the command tree is still built, so that help remains
+	// available and commands that don't depend on a valid config, eg
+	// config lint, can still run. The error is returned when a command
+	// is executed.
+	err error
 }
 
 type ConfigureOptionsInfo struct {
@@ -109,18 +121,21 @@ func (b *Bootstrap) Root(options ...ConfigureOptionFn) *cobra.Command {
 	)
 
 	if err != nil {
-		// not being able to access the default path is pretty catastrophic,
-		// so will terminate immediately if this happens.
+		// without the home path, the config can't be located, so i18n is
+		// initialised with the default language, which allows the error
+		// to be reported.
 		//
-		fmt.Println("---> 🔥 can't access home path, terminating.")
-		os.Exit(1)
+		b.err = lo.Ternary(handleLangSetting(ci.Viper) == nil,
+			error(locale.NewUnresolvedHomePathError(err)), err,
+		)
+	} else {
+		b.OptionsInfo.Runner = runner
+		b.err = b.initialise()
 	}
 
-	b.OptionsInfo.Runner = runner
-
-	b.configure()
-	b.viper()
-	b.Logger = plog.New(b.Configs.Logging, b.Vfs, ci.Scope, vc)
+	if b.Logger == nil {
+		b.Logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
 
 	b.Container = assistant.NewCobraContainer(
 		&cobra.Command{
@@ -128,6 +143,9 @@ func (b *Bootstrap) Root(options ...ConfigureOptionFn) *cobra.Command {
 			Short:   xi18n.Text(locale.RootCmdShortDescTemplData{}),
 			Long:    xi18n.Text(locale.RootCmdLongDescTemplData{}),
 			Version: fmt.Sprintf("'%v'", Version),
+			PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+				return b.prepare(cmd, args)
+			},
 			RunE: func(_ *cobra.Command, args []string) error {
 				inputs := b.getRootInputs()
 
@@ -172,41 +190,242 @@ func (b *Bootstrap) Root(options ...ConfigureOptionFn) *cobra.Command {
 	b.buildRootCommand(b.Container)
 	b.buildMagickCommand(b.Container)
 	b.buildShrinkCommand(b.Container)
+	b.buildReportCommand(b.Container)
+	b.buildRecommendCommand(b.Container)
+	b.buildProfilesCommand(b.Container)
+	b.buildSchemesCommand(b.Container)
+	b.buildConfigCommand(b.Container)
+	b.buildApplyCommand(b.Container)
+	b.buildDupesCommand(b.Container)
 
 	return b.Container.Root()
 }
 
-func (b *Bootstrap) configure() {
+// initialise loads the config and creates the logger. An error reading
+// the config doesn't prevent the logger from being created, but the
+// default config is not a substitute for the user's, so the logger
+// isn't created either.
+func (b *Bootstrap) initialise() error {
+	if err := b.configure(); err != nil {
+		return err
+	}
+
+	if err := b.layer(); err != nil {
+		return err
+	}
+
+	if err := b.viper(); err != nil {
+		return err
+	}
+
+	logger, err := plog.New(b.Configs.Logging, b.Vfs,
+		b.OptionsInfo.Config.Scope, b.OptionsInfo.Config.Viper,
+	)
+	if err != nil {
+		return err
+	}
+
+	b.Logger = logger
+	b.warnLegacy()
+
+	return nil
+}
+
+// warnLegacy reports the keys of a config that has not been migrated.
+// They still take effect, but only until they are no longer supported.
+func (b *Bootstrap) warnLegacy() {
+	for _, layer := range b.Layers {
+		for _, key := range layer.Legacy {
+			b.Logger.Warn("legacy config key, run 'pixa config migrate' to replace it",
+				slog.String("key", key),
+				slog.String("config", layer.Path),
+			)
+		}
+	}
+}
+
+// dataPath returns the path of the file in the pixa data directory, which
+// follows the same convention as the log file. An empty path is returned
+// when the data directory can't be determined, which means the file is
+// not used.
+func (b *Bootstrap) dataPath(filename string) string {
+	if common.IsUsingXDG(b.OptionsInfo.Config.Viper) {
+		return utils.ResolvePath(filepath.Join(
+			"~", ".local", "share", common.Definitions.Pixa.AppName, filename,
+		))
+	}
+
+	path, err := b.OptionsInfo.Config.Scope.DataPath(filename)
+	if err != nil {
+		b.Logger.Warn("could not determine data directory",
+			slog.String("file", filename),
+			slog.String("error", err.Error()),
+		)
+
+		return ""
+	}
+
+	return path
+}
+
+// prepare is invoked prior to any command being run. It reports the
+// initialisation error, if there is one, otherwise merges in the project
+// config. The flags and args have already been parsed by this stage, so
+// any error from here on is not the result of invoking the command
+// incorrectly, therefore usage is not shown.
+func (b *Bootstrap) prepare(cmd *cobra.Command, args []string) error {
+	cmd.SilenceUsage = true
+
+	if b.err != nil {
+		return b.err
+	}
+
+	return b.project(args)
+}
+
+func (b *Bootstrap) configure() error {
 	if err := b.OptionsInfo.Runner.Run(); err != nil {
-		msg := xi18n.Text(locale.UsingConfigFileTemplData{
-			ConfigFileName: b.OptionsInfo.Config.Viper.ConfigFileUsed(),
-		})
+		return locale.NewInvalidConfigError(b.configFileDescription(), err)
+	}
 
-		fmt.Fprintln(os.Stderr, msg)
-		fmt.Printf("💥 error reading config path: '%v' \n", err)
-		b.exit(err)
+	return nil
+}
+
+// layer merges the config file in use over the embedded default config,
+// so that any value missing from the file takes its default, then
+// applies the environment over both.
+func (b *Bootstrap) layer() error {
+	files, err := b.readLayers()
+
+	if err == nil {
+		b.Layers, err = b.compose(files)
 	}
+
+	if err != nil {
+		return locale.NewInvalidConfigError(b.configFileDescription(), err)
+	}
+
+	return nil
 }
 
-func handleLangSetting(config configuration.ViperConfig) {
-	tag := lo.TernaryF(config.InConfig("lang"),
-		func() language.Tag {
-			lang := viper.GetString("lang")
-			parsedTag, err := language.Parse(lang)
+// compose completes the config file layers with the environment, which
+// takes precedence over all of them, and applies the result to viper. The
+// precedence of the config is therefore; flag > env > project config >
+// user config > embedded default. Flags are not a layer, instead each
+// command falls back to the config for any flag not specified (see
+// fallback).
+func (b *Bootstrap) compose(files cfg.ConfigLayers) (cfg.ConfigLayers, error) {
+	envLayer, err := cfg.EnvLayer(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
 
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
+	layers := append(slices.Clone(files), envLayer)
 
-			return parsedTag
-		},
-		func() language.Tag {
-			return xi18n.DefaultLanguage.Get()
-		},
+	return layers, layers.Apply(b.OptionsInfo.Config.Viper)
+}
+
+func (b *Bootstrap) readLayers() (cfg.ConfigLayers, error) {
+	defaultLayer, err := cfg.DefaultLayer()
+	if err != nil {
+		return nil, err
+	}
+
+	layers := cfg.ConfigLayers{defaultLayer}
+
+	if used := b.OptionsInfo.Config.Viper.ConfigFileUsed(); used != "" {
+		userLayer, err := cfg.ReadLayer(b.Vfs, cfg.LayerUser, used)
+		if err != nil {
+			return nil, err
+		}
+
+		layers = append(layers, userLayer)
+	}
+
+	return layers, nil
+}
+
+// project merges the project config, if there is one, over the user
+// config, beneath the environment. The project config is discovered from the target directory,
+// which is the first arg when it denotes a directory, otherwise the
+// current working directory, so this can only be done once the args
+// have been parsed. The logger has already been created by this stage,
+// so the logging settings of the project config do not take effect.
+func (b *Bootstrap) project(args []string) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	if len(args) > 0 {
+		if target := utils.ResolvePath(args[0]); b.Vfs.DirectoryExists(target) {
+			dir = target
+		}
+	}
+
+	path, found := cfg.FindProjectConfig(b.Vfs, dir)
+	if !found {
+		return nil
+	}
+
+	projectLayer, err := cfg.ReadLayer(b.Vfs, cfg.LayerProject, path)
+	if err != nil {
+		return locale.NewInvalidConfigError(path, err)
+	}
+
+	layers, err := b.compose(append(b.Layers.Without(cfg.LayerEnv), projectLayer))
+	if err != nil {
+		return locale.NewInvalidConfigError(path, err)
+	}
+
+	var (
+		m cfg.MsMasterConfig
 	)
 
-	err := xi18n.Use(func(uo *xi18n.UseOptions) {
+	configs, err := m.Read(b.OptionsInfo.Config.Viper)
+	if err != nil {
+		return locale.NewInvalidConfigError(path, err)
+	}
+
+	b.Layers, b.Configs = layers, configs
+
+	return nil
+}
+
+// fallback assigns the config value to the target of the flag, when the
+// flag has not been specified on the command line, which is what gives a
+// flag precedence over config. Viper is supposed to do this transparently,
+// but it doesn't work for flags whose config key is in a custom location;
+// ie no-files is defined under sampler, but viper would expect to see it at
+// the root. A zero config value denotes that it is not set, so the default
+// of the flag remains in effect.
+func fallback[T comparable](flagSet *pflag.FlagSet, flag string, target *T, value T) {
+	var (
+		zero T
+	)
+
+	if !flagSet.Changed(flag) && value != zero {
+		*target = value
+	}
+}
+
+// handleLangSetting initialises i18n when the config runner is not
+// available to do so.
+func handleLangSetting(config configuration.ViperConfig) error {
+	tag := xi18n.DefaultLanguage.Get()
+
+	if config.InConfig("lang") {
+		lang := viper.GetString("lang")
+		parsedTag, err := language.Parse(lang)
+
+		if err != nil {
+			return fmt.Errorf("lang: '%v' (%w)", lang, err)
+		}
+
+		tag = parsedTag
+	}
+
+	return xi18n.Use(func(uo *xi18n.UseOptions) {
 		uo.Tag = tag
 		uo.From = xi18n.LoadFrom{
 			Sources: xi18n.TranslationFiles{
@@ -219,29 +438,17 @@ func handleLangSetting(config configuration.ViperConfig) {
 			},
 		}
 	})
-
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
 }
 
-func (b *Bootstrap) viper() {
+func (b *Bootstrap) viper() error {
 	var (
 		err error
 		m   cfg.MsMasterConfig
 	)
 
-	b.Configs, err = m.Read(b.OptionsInfo.Config.Viper)
-
-	// TODO: this needs a refactor to handle errors better, we should be returning errors everywhere
-	// an error can occur, not selectively. this method is a perfect bad example.
-
-	if err != nil {
-		b.exit(err)
+	if b.Configs, err = m.Read(b.OptionsInfo.Config.Viper); err != nil {
+		return locale.NewInvalidConfigError(b.configFileDescription(), err)
 	}
-}
 
-func (b *Bootstrap) exit(err error) {
-	panic(err)
+	return nil
 }
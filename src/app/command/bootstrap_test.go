@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
 	. "github.com/onsi/gomega"    //nolint:revive // foo
 	"github.com/snivilised/cobrass/src/assistant/configuration"
+	xi18n "github.com/snivilised/extendio/i18n"
 	"github.com/snivilised/extendio/xfs/storage"
 	"github.com/snivilised/pixa/src/app/command"
 	"github.com/snivilised/pixa/src/app/proxy/common"
@@ -70,4 +71,46 @@ var _ = Describe("Bootstrap", Ordered, func() {
 			Expect(rootCmd).NotTo(BeNil())
 		})
 	})
+
+	Context("given: invalid config", func() {
+		var (
+			bootstrap command.Bootstrap
+		)
+
+		BeforeEach(func() {
+			xi18n.ResetTx()
+			bootstrap = command.Bootstrap{
+				Vfs: vfs,
+			}
+		})
+
+		root := func(args ...string) error {
+			rootCmd := bootstrap.Root(func(co *command.ConfigureOptionsInfo) {
+				co.Detector = &DetectorStub{}
+				co.Config.Name = common.Definitions.Pixa.ConfigTestFilename + "-invalid"
+				co.Config.ConfigPath = configPath
+				co.Config.Viper = &configuration.GlobalViperConfig{}
+			})
+			Expect(rootCmd).NotTo(BeNil())
+
+			tester := helpers.CommandTester{
+				Args: args,
+				Root: rootCmd,
+			}
+			_, err := tester.Execute()
+
+			return err
+		}
+
+		It("🧪 should: return config error instead of panicking", func() {
+			err := root("profiles", "list")
+
+			Expect(err).NotTo(Succeed())
+			Expect(command.ExitCode(err)).To(Equal(command.ExitConfig))
+		})
+
+		It("🧪 should: still run config commands", func() {
+			Expect(root("config", "path")).To(Succeed())
+		})
+	})
 })
@@ -0,0 +1,68 @@
+package command_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+
+	"github.com/snivilised/cobrass/src/assistant/configuration"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/command"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/internal/helpers"
+)
+
+// commandFixture runs commands against the test config, within a file
+// system populated from the scientist index, which is re-created for each
+// spec.
+type commandFixture struct {
+	configPath string
+	l10nPath   string
+	root       string
+	vfs        storage.VirtualFS
+}
+
+// useCommandFixture registers the setup of the fixture with the container
+// it is invoked from.
+func useCommandFixture() *commandFixture {
+	f := &commandFixture{}
+
+	BeforeAll(func() {
+		repo := helpers.Repo("")
+		f.l10nPath = helpers.Path(repo, "test/data/l10n")
+		f.configPath = helpers.Path(repo, "test/data/configuration")
+	})
+
+	BeforeEach(func() {
+		xi18n.ResetTx()
+		f.vfs, f.root = helpers.SetupTest(
+			"nasa-scientist-index.xml", f.configPath, f.l10nPath, helpers.Silent,
+		)
+	})
+
+	return f
+}
+
+// execute runs the command line against the test config and returns its
+// output.
+func (f *commandFixture) execute(args ...string) (string, error) {
+	return f.executeWith(common.Definitions.Pixa.ConfigTestFilename, args...)
+}
+
+// executeWith runs the command line against the named config, found in
+// the test config directory.
+func (f *commandFixture) executeWith(config string, args ...string) (string, error) {
+	bootstrap := command.Bootstrap{
+		Vfs: f.vfs,
+	}
+	tester := helpers.CommandTester{
+		Args: args,
+		Root: bootstrap.Root(func(co *command.ConfigureOptionsInfo) {
+			co.Detector = &DetectorStub{}
+			co.Config.Name = config
+			co.Config.ConfigPath = f.configPath
+			co.Config.Viper = &configuration.GlobalViperConfig{}
+		}),
+	}
+
+	return tester.Execute()
+}
@@ -0,0 +1,549 @@
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/snivilised/cobrass"
+	"github.com/snivilised/cobrass/src/assistant"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var configShortFlags = cobrass.KnownByCollection{
+	"scope":     "s",
+	"force":     "f",
+	"effective": "e",
+}
+
+const (
+	configInitPsName = "config-init-ps"
+	configShowPsName = "config-show-ps"
+
+	// defaultEditor is used by config edit when neither VISUAL nor EDITOR
+	// is defined.
+	defaultEditor = "vi"
+)
+
+func newConfigFlagInfoWithShort[T any](usage string, defaultValue T) *assistant.FlagInfo {
+	name := strings.Split(usage, " ")[0]
+	short := configShortFlags[name]
+
+	return assistant.NewFlagInfo(usage, short, defaultValue)
+}
+
+type configParameterSetPtr = *assistant.ParamSet[common.ConfigParameterSet]
+
+func (b *Bootstrap) buildConfigCommand(container *assistant.CobraContainer) *cobra.Command {
+	configCommand := &cobra.Command{
+		Use: common.Definitions.Commands.Config,
+		Short: locale.LeadsWith(
+			common.Definitions.Commands.Config,
+			xi18n.Text(locale.ConfigCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.ConfigLongDefinitionTemplData{}),
+
+		// the config commands are the means by which an invalid config is
+		// diagnosed and repaired, so they must be able to run in spite of
+		// it. Those that depend on the config being valid, report the
+		// error themselves.
+		//
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				invalid locale.InvalidConfigErrorBehaviourQuery
+			)
+
+			if errors.As(b.err, &invalid) {
+				cmd.SilenceUsage = true
+
+				return nil
+			}
+
+			return b.prepare(cmd, args)
+		},
+	}
+
+	container.MustRegisterRootedCommand(configCommand)
+
+	// the container identifies commands by name, but the sub commands of
+	// config share their names with those of other commands, so they are
+	// added to their parent directly.
+	//
+	configCommand.AddCommand(
+		b.buildConfigInitCommand(container),
+		b.buildConfigShowCommand(container),
+		b.buildConfigPathCommand(container),
+		b.buildConfigEditCommand(container),
+		b.buildConfigMigrateCommand(container),
+		b.buildConfigLintCommand(container),
+		b.buildConfigSchemaCommand(container),
+	)
+
+	return configCommand
+}
+
+func (b *Bootstrap) buildConfigInitCommand(container *assistant.CobraContainer) *cobra.Command {
+	initCommand := &cobra.Command{
+		Use: "init",
+		Short: locale.LeadsWith(
+			"init",
+			xi18n.Text(locale.ConfigInitCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			initPS := container.MustGetParamSet(configInitPsName).(configParameterSetPtr) //nolint:errcheck // is Must call
+
+			if err := initPS.Validate(); err != nil {
+				return err
+			}
+
+			dir, err := b.OptionsInfo.Runner.ScopePath(initPS.Native.ScopeEn.Value())
+			if err != nil {
+				return err
+			}
+
+			path := filepath.Join(dir, common.Definitions.Defaults.Config.ConfigFilename)
+
+			if b.Vfs.FileExists(path) && !initPS.Native.Force {
+				return fmt.Errorf("config file: '%v' already exists (use --force to overwrite)", path)
+			}
+
+			if err := b.Vfs.MkdirAll(dir, common.Permissions.Write); err != nil {
+				return err
+			}
+
+			if err := b.Vfs.WriteFile(
+				path, []byte(cfg.GetDefaultConfigContent()), common.Permissions.Write,
+			); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✨ wrote config file: '%v'\n", path)
+
+			return nil
+		},
+	}
+
+	paramSet := assistant.NewParamSet[common.ConfigParameterSet](initCommand)
+
+	// --scope(s)
+	//
+	const (
+		defaultScope = "default"
+	)
+
+	paramSet.Native.ScopeEn = common.ConfigScopeEnumInfo.NewValue()
+
+	paramSet.BindValidatedEnum(
+		newConfigFlagInfoWithShort(
+			xi18n.Text(locale.ConfigInitCmdScopeParamUsageTemplData{}),
+			defaultScope,
+		),
+		&paramSet.Native.ScopeEn.Source,
+		func(value string, f *pflag.Flag) error {
+			if f.Changed && !(common.ConfigScopeEnumInfo.IsValid(value)) {
+				acceptableSet := common.ConfigScopeEnumInfo.AcceptablePrimes()
+
+				return locale.NewInvalidConfigScopeError(value, acceptableSet)
+			}
+
+			return nil
+		},
+	)
+
+	// --force(f)
+	//
+	const (
+		defaultForce = false
+	)
+
+	paramSet.BindBool(
+		newConfigFlagInfoWithShort(
+			xi18n.Text(locale.ConfigInitCmdForceParamUsageTemplData{}),
+			defaultForce,
+		),
+		&paramSet.Native.Force,
+	)
+
+	container.MustRegisterParamSet(configInitPsName, paramSet)
+
+	return initCommand
+}
+
+func (b *Bootstrap) buildConfigShowCommand(container *assistant.CobraContainer) *cobra.Command {
+	showCommand := &cobra.Command{
+		Use: "show",
+		Short: locale.LeadsWith(
+			"show",
+			xi18n.Text(locale.ConfigShowCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			showPS := container.MustGetParamSet(configShowPsName).(configParameterSetPtr) //nolint:errcheck // is Must call
+
+			if err := showPS.Validate(); err != nil {
+				return err
+			}
+
+			if b.err != nil {
+				return b.err
+			}
+
+			if showPS.Native.Effective {
+				return b.tabulateEffective(cmd.OutOrStdout())
+			}
+
+			for _, layer := range b.Layers {
+				if layer.Path != "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "# %v: %v\n", layer.Name, layer.Path)
+				}
+			}
+
+			const (
+				indent = 2
+			)
+
+			encoder := yaml.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent(indent)
+
+			if err := encoder.Encode(b.Layers.Merge()); err != nil {
+				return err
+			}
+
+			return encoder.Close()
+		},
+	}
+
+	paramSet := assistant.NewParamSet[common.ConfigParameterSet](showCommand)
+
+	// --effective(e)
+	//
+	const (
+		defaultEffective = false
+	)
+
+	paramSet.BindBool(
+		newConfigFlagInfoWithShort(
+			xi18n.Text(locale.ConfigShowCmdEffectiveParamUsageTemplData{}),
+			defaultEffective,
+		),
+		&paramSet.Native.Effective,
+	)
+
+	container.MustRegisterParamSet(configShowPsName, paramSet)
+
+	return showCommand
+}
+
+func (b *Bootstrap) buildConfigPathCommand(_ *assistant.CobraContainer) *cobra.Command {
+	pathCommand := &cobra.Command{
+		Use: "path",
+		Short: locale.LeadsWith(
+			"path",
+			xi18n.Text(locale.ConfigPathCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			dirs, err := b.OptionsInfo.Runner.SearchPaths()
+			if err != nil {
+				return err
+			}
+
+			const (
+				minWidth = 0
+				tabWidth = 4
+				padding  = 2
+			)
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), minWidth, tabWidth, padding, ' ', 0)
+			used := b.OptionsInfo.Config.Viper.ConfigFileUsed()
+			filename := fmt.Sprintf("%v.%v",
+				b.OptionsInfo.Config.Name, b.OptionsInfo.Config.ConfigType,
+			)
+
+			fmt.Fprintln(tw, "ORDER\tPATH\tSTATUS")
+
+			for i, dir := range dirs {
+				path := filepath.Join(dir, filename)
+				status := "-"
+
+				switch {
+				case path == used:
+					status = "★ in use"
+
+				case b.Vfs.FileExists(path):
+					status = "✔ found"
+				}
+
+				fmt.Fprintf(tw, "%v\t%v\t%v\n", i+1, path, status)
+			}
+
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "\nusing: %v\n", b.configFileDescription())
+
+			if layer, found := b.Layers.Find(cfg.LayerProject); found {
+				fmt.Fprintf(cmd.OutOrStdout(), "project: %v\n", layer.Path)
+			}
+
+			return nil
+		},
+	}
+
+	return pathCommand
+}
+
+func (b *Bootstrap) buildConfigEditCommand(_ *assistant.CobraContainer) *cobra.Command {
+	editCommand := &cobra.Command{
+		Use: "edit",
+		Short: locale.LeadsWith(
+			"edit",
+			xi18n.Text(locale.ConfigEditCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, err := b.configFileUsed()
+			if err != nil {
+				return err
+			}
+
+			editor := defaultEditor
+
+			for _, name := range []string{"VISUAL", "EDITOR"} {
+				if value := os.Getenv(name); value != "" {
+					editor = value
+
+					break
+				}
+			}
+
+			// the editor may be defined with arguments, eg "code --wait"
+			//
+			fields := strings.Fields(editor)
+			editorCmd := exec.Command(fields[0], append(fields[1:], path)...) //nolint:gosec // editor is chosen by the user
+			editorCmd.Stdin = cmd.InOrStdin()
+			editorCmd.Stdout = cmd.OutOrStdout()
+			editorCmd.Stderr = cmd.ErrOrStderr()
+
+			return editorCmd.Run()
+		},
+	}
+
+	return editCommand
+}
+
+func (b *Bootstrap) buildConfigMigrateCommand(_ *assistant.CobraContainer) *cobra.Command {
+	migrateCommand := &cobra.Command{
+		Use: "migrate",
+		Short: locale.LeadsWith(
+			"migrate",
+			xi18n.Text(locale.ConfigMigrateCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			path, err := b.configFileUsed()
+			if err != nil {
+				return err
+			}
+
+			dryRun := b.getRootInputs().PreviewFam.Native.DryRun
+			result, err := cfg.Migrate(b.Vfs, path, dryRun)
+
+			if err != nil {
+				return err
+			}
+
+			if len(result.Changes) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "✅ config file: '%v' is up to date\n", path)
+
+				return nil
+			}
+
+			for _, change := range result.Changes {
+				fmt.Fprintf(cmd.OutOrStdout(), "🔧 %v\n", change)
+			}
+
+			if dryRun {
+				fmt.Fprintf(cmd.OutOrStdout(), "🧪 dry run, config file: '%v' not modified\n", path)
+
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✨ migrated config file: '%v' (backup: '%v')\n",
+				path, result.Backup,
+			)
+
+			return nil
+		},
+	}
+
+	return migrateCommand
+}
+
+func (b *Bootstrap) buildConfigLintCommand(_ *assistant.CobraContainer) *cobra.Command {
+	lintCommand := &cobra.Command{
+		Use: "lint [file]",
+		Short: locale.LeadsWith(
+			"lint",
+			xi18n.Text(locale.ConfigLintCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.MaximumNArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				path string
+				err  error
+			)
+
+			if len(args) > 0 {
+				path = utils.ResolvePath(args[0])
+			} else if path, err = b.configFileUsed(); err != nil {
+				return err
+			}
+
+			issues, err := cfg.Lint(b.Vfs, path)
+			if err != nil {
+				return err
+			}
+
+			for _, issue := range issues {
+				fmt.Fprintf(cmd.OutOrStdout(), "❌ %v: %v\n", path, issue)
+			}
+
+			if len(issues) > 0 {
+				return locale.NewInvalidConfigError(path,
+					fmt.Errorf("found %v problem(s)", len(issues)),
+				)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "✅ config file: '%v' is valid\n", path)
+
+			return nil
+		},
+	}
+
+	return lintCommand
+}
+
+func (b *Bootstrap) buildConfigSchemaCommand(_ *assistant.CobraContainer) *cobra.Command {
+	schemaCommand := &cobra.Command{
+		Use: "schema",
+		Short: locale.LeadsWith(
+			"schema",
+			xi18n.Text(locale.ConfigSchemaCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			encoder.SetIndent("", "  ")
+
+			return encoder.Encode(cfg.GenerateSchema())
+		},
+	}
+
+	return schemaCommand
+}
+
+// tabulateEffective writes each of the merged config values along with the
+// layer it comes from.
+func (b *Bootstrap) tabulateEffective(w io.Writer) error {
+	const (
+		minWidth = 0
+		tabWidth = 4
+		padding  = 2
+	)
+
+	tw := tabwriter.NewWriter(w, minWidth, tabWidth, padding, ' ', 0)
+	merged := b.Layers.Merge()
+
+	fmt.Fprintln(tw, "KEY\tVALUE\tSOURCE")
+
+	for _, key := range cfg.LeafKeys(merged) {
+		value, _ := cfg.ValueAt(merged, key)
+		source := "-"
+
+		if layer, found := b.Layers.Source(key); found {
+			source = describeLayer(layer, key)
+		}
+
+		fmt.Fprintf(tw, "%v\t%v\t%v\n", key, formatValue(value), source)
+	}
+
+	return tw.Flush()
+}
+
+func (b *Bootstrap) configFileUsed() (string, error) {
+	if used := b.OptionsInfo.Config.Viper.ConfigFileUsed(); used != "" {
+		return used, nil
+	}
+
+	return "", errors.New("no config file in use (create one with 'pixa config init')")
+}
+
+func (b *Bootstrap) configFileDescription() string {
+	if used := b.OptionsInfo.Config.Viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+
+	return "embedded default config"
+}
+
+func describeLayer(layer *cfg.ConfigLayer, key string) string {
+	switch {
+	case layer.Name == cfg.LayerEnv:
+		return fmt.Sprintf("%v (%v)", layer.Name, cfg.EnvName(key))
+
+	case layer.Path == "":
+		return layer.Name
+	}
+
+	return fmt.Sprintf("%v (%v)", layer.Name, layer.Path)
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "~"
+
+	case string:
+		if v == "" {
+			return `""`
+		}
+
+	case []any:
+		items := make([]string, 0, len(v))
+
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		return "[" + strings.Join(items, ", ") + "]"
+
+	case map[string]any:
+		return "{}"
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,35 @@
+package command_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+)
+
+var _ = Describe("ConfigCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	DescribeTable("sub commands",
+		func(args []string, shouldFail bool) {
+			_, err := fixture.execute(args...)
+
+			if shouldFail {
+				Expect(err).Error().NotTo(BeNil())
+			} else {
+				Expect(err).Error().To(BeNil())
+			}
+		},
+		func(args []string, shouldFail bool) string {
+			return fmt.Sprintf("🧪 ===> args: '%v', should fail: '%v'", args, shouldFail)
+		},
+		Entry(nil, []string{"config", "show"}, false),
+		Entry(nil, []string{"config", "show", "--effective"}, false),
+		Entry(nil, []string{"config", "path"}, false),
+		Entry(nil, []string{"config", "init"}, false),
+		Entry(nil, []string{"config", "init", "--scope", "xdg"}, false),
+		Entry(nil, []string{"config", "init", "--scope", "foo"}, true),
+		Entry(nil, []string{"config", "schema"}, false),
+		Entry(nil, []string{"config", "lint", "missing.yml"}, true),
+	)
+})
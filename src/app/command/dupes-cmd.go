@@ -0,0 +1,178 @@
+package command
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/snivilised/cobrass"
+	"github.com/snivilised/cobrass/src/assistant"
+	"github.com/snivilised/cobrass/src/store"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/snivilised/pixa/src/app/proxy"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var dupesShortFlags = cobrass.KnownByCollection{
+	"threshold":   "t",
+	"action":      "a",
+	"report-file": "r",
+}
+
+const (
+	dupesPsName       = "dupes-ps"
+	dupesPolyFamName  = "dupes-poly-family"
+	defaultThreshold  = uint(10)
+	maxDupesThreshold = uint(64)
+)
+
+func newDupesFlagInfoWithShort[T any](usage string, defaultValue T) *assistant.FlagInfo {
+	name := strings.Split(usage, " ")[0]
+	short := dupesShortFlags[name]
+
+	return assistant.NewFlagInfo(usage, short, defaultValue)
+}
+
+type dupesParameterSetPtr = *assistant.ParamSet[common.DupesParameterSet]
+
+func (b *Bootstrap) buildDupesCommand(container *assistant.CobraContainer) *cobra.Command {
+	dupesCommand := &cobra.Command{
+		Use: "dupes",
+		Short: locale.LeadsWith(
+			"dupes",
+			xi18n.Text(locale.DupesCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.DupesLongDefinitionTemplData{}),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(_ *cobra.Command, args []string) error {
+			dupesPS := container.MustGetParamSet(dupesPsName).(dupesParameterSetPtr) //nolint:errcheck // is Must call
+
+			if err := dupesPS.Validate(); err != nil {
+				return err
+			}
+
+			b.Logger.Info(
+				fmt.Sprintf("%v %v running dupes",
+					common.Definitions.Pixa.AppName, common.Definitions.Pixa.Emoji,
+				),
+				slog.String("args", strings.Join(args, "/")),
+			)
+
+			inputs := b.getDupesInputs()
+			inputs.Root.ParamSet.Native.Directory = utils.ResolvePath(args[0])
+
+			if path := dupesPS.Native.ReportPath; path != "" {
+				dupesPS.Native.ReportPath = utils.ResolvePath(path)
+			}
+
+			_, err := proxy.EnterDupes(
+				&proxy.DupesParams{
+					Inputs: inputs,
+					Viper:  b.OptionsInfo.Config.Viper,
+					Logger: b.Logger,
+					Vfs:    b.Vfs,
+				},
+			)
+
+			return err
+		},
+	}
+
+	paramSet := assistant.NewParamSet[common.DupesParameterSet](dupesCommand)
+
+	// --threshold(t)
+	//
+	paramSet.BindValidatedUintWithin(
+		newDupesFlagInfoWithShort(
+			xi18n.Text(locale.DupesCmdThresholdParamUsageTemplData{}),
+			defaultThreshold,
+		),
+		&paramSet.Native.Threshold,
+		0,
+		maxDupesThreshold,
+	)
+
+	// --exact
+	//
+	const (
+		defaultExact = false
+	)
+
+	paramSet.BindBool(
+		newDupesFlagInfoWithShort(
+			xi18n.Text(locale.DupesCmdExactParamUsageTemplData{}),
+			defaultExact,
+		),
+		&paramSet.Native.Exact,
+	)
+
+	// --action(a)
+	//
+	const (
+		defaultAction = "none"
+	)
+
+	paramSet.Native.ActionEn = common.DupesActionEnumInfo.NewValue()
+
+	paramSet.BindValidatedEnum(
+		newDupesFlagInfoWithShort(
+			xi18n.Text(locale.DupesCmdActionParamUsageTemplData{}),
+			defaultAction,
+		),
+		&paramSet.Native.ActionEn.Source,
+		func(value string, f *pflag.Flag) error {
+			if f.Changed && !(common.DupesActionEnumInfo.IsValid(value)) {
+				acceptableSet := common.DupesActionEnumInfo.AcceptablePrimes()
+
+				return locale.NewInvalidDupesActionError(value, acceptableSet)
+			}
+
+			return nil
+		},
+	)
+
+	// --report-file(r)
+	//
+	const (
+		defaultReportPath = ""
+	)
+
+	paramSet.BindString(
+		newDupesFlagInfoWithShort(
+			xi18n.Text(locale.DupesCmdReportFileParamUsageTemplData{}),
+			defaultReportPath,
+		),
+		&paramSet.Native.ReportPath,
+	)
+
+	// family: poly [--files(F), --files-rx(X)]
+	//
+	// The same file filters as shrink, so that both see the same images.
+	//
+	polyFam := assistant.NewParamSet[store.PolyFilterParameterSet](dupesCommand)
+	polyFam.Native.BindAll(polyFam)
+
+	container.MustRegisterRootedCommand(dupesCommand)
+	container.MustRegisterParamSet(dupesPsName, paramSet)
+	container.MustRegisterParamSet(dupesPolyFamName, polyFam)
+
+	return dupesCommand
+}
+
+func (b *Bootstrap) getDupesInputs() *common.DupesCommandInputs {
+	return &common.DupesCommandInputs{
+		Root: b.getRootInputs(),
+		ParamSet: b.Container.MustGetParamSet(
+			dupesPsName,
+		).(*assistant.ParamSet[common.DupesParameterSet]),
+		PolyFam: b.Container.MustGetParamSet(
+			dupesPolyFamName,
+		).(*assistant.ParamSet[store.PolyFilterParameterSet]),
+	}
+}
@@ -0,0 +1,48 @@
+package command_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/internal/helpers"
+	"github.com/snivilised/pixa/src/internal/matchers"
+)
+
+var _ = Describe("DupesCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	execute := func(args ...string) error {
+		_, err := fixture.execute(append([]string{common.Definitions.Commands.Dupes}, args...)...)
+
+		return err
+	}
+
+	When("directory contains images", func() {
+		It("🧪 should: write report without error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+			reportFile := filepath.Join(fixture.root, "dupes.json")
+
+			Expect(execute(directory, "--report-file", reportFile)).Error().To(BeNil())
+			Expect(matchers.AsFile(reportFile)).To(matchers.ExistInFS(fixture.vfs))
+		})
+	})
+
+	When("action is not valid", func() {
+		It("🧪 should: return error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+
+			Expect(execute(directory, "--action", "shred")).Error().NotTo(BeNil())
+		})
+	})
+
+	When("threshold is out of range", func() {
+		It("🧪 should: return error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+
+			Expect(execute(directory, "--threshold", "65")).Error().NotTo(BeNil())
+		})
+	})
+})
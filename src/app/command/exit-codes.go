@@ -0,0 +1,76 @@
+package command
+
+import (
+	"errors"
+
+	"github.com/snivilised/pixa/src/locale"
+)
+
+// The exit codes of pixa. Any error that doesn't fall into one of the
+// documented categories results in ExitFailure.
+const (
+	// ExitOK denotes success
+	ExitOK = 0
+
+	// ExitFailure denotes an error not covered by any other category,
+	// eg an invalid flag.
+	ExitFailure = 1
+
+	// ExitConfig denotes that the config could not be loaded; ie the user
+	// config, project config or environment overrides are invalid.
+	ExitConfig = 2
+
+	// ExitInitialisation denotes that pixa could not be initialised, eg
+	// the home path could not be resolved or the log file created.
+	ExitInitialisation = 3
+
+	// ExitMissingExecutable denotes that the program configured to
+	// perform the shrink (advanced.executable.program-name) is not
+	// installed.
+	ExitMissingExecutable = 4
+
+	// ExitPartialFailure denotes that the run completed, but some of
+	// the items it processed failed, or that it was aborted because the
+	// number of failed items reached advanced.max-failures.
+	ExitPartialFailure = 5
+
+	// ExitUserAbort denotes that the user aborted the run before it
+	// completed. The value is the one conventionally used by shells for
+	// a process interrupted by ctrl-c.
+	ExitUserAbort = 130
+)
+
+// ExitCode returns the exit code that represents the category of err.
+func ExitCode(err error) int {
+	var (
+		config     locale.InvalidConfigErrorBehaviourQuery
+		home       locale.UnresolvedHomePathErrorBehaviourQuery
+		logFile    locale.LogFileErrorBehaviourQuery
+		executable locale.MissingExecutableErrorBehaviourQuery
+		partial    locale.PartialFailureErrorBehaviourQuery
+		maxed      locale.MaxFailuresReachedErrorBehaviourQuery
+		abort      locale.UserAbortErrorBehaviourQuery
+	)
+
+	switch {
+	case err == nil:
+		return ExitOK
+
+	case errors.As(err, &config):
+		return ExitConfig
+
+	case errors.As(err, &home), errors.As(err, &logFile):
+		return ExitInitialisation
+
+	case errors.As(err, &executable):
+		return ExitMissingExecutable
+
+	case errors.As(err, &partial), errors.As(err, &maxed):
+		return ExitPartialFailure
+
+	case errors.As(err, &abort):
+		return ExitUserAbort
+	}
+
+	return ExitFailure
+}
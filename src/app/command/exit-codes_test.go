@@ -0,0 +1,35 @@
+package command_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/command"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var _ = Describe("ExitCode", func() {
+	DescribeTable("error categories",
+		func(err error, expected int) {
+			Expect(command.ExitCode(err)).To(Equal(expected))
+		},
+		func(err error, expected int) string {
+			return fmt.Sprintf("🧪 ===> exit code: '%v'", expected)
+		},
+		Entry(nil, nil, command.ExitOK),
+		Entry(nil, errors.New("foo"), command.ExitFailure),
+		Entry(nil, locale.NewInvalidConfigError("pixa.yml", errors.New("foo")), command.ExitConfig),
+		Entry(nil, fmt.Errorf("wrapped: %w",
+			locale.NewInvalidConfigError("pixa.yml", errors.New("foo")),
+		), command.ExitConfig),
+		Entry(nil, locale.NewUnresolvedHomePathError(errors.New("foo")), command.ExitInitialisation),
+		Entry(nil, locale.NewLogFileError("pixa.log", errors.New("foo")), command.ExitInitialisation),
+		Entry(nil, locale.NewMissingExecutableError("magick"), command.ExitMissingExecutable),
+		Entry(nil, locale.NewPartialFailureError(1, 2), command.ExitPartialFailure),
+		Entry(nil, locale.NewMaxFailuresReachedError(3), command.ExitPartialFailure),
+		Entry(nil, locale.NewUserAbortError(), command.ExitUserAbort),
+	)
+})
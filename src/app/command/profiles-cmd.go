@@ -0,0 +1,260 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/samber/lo"
+	"github.com/snivilised/cobrass"
+	"github.com/snivilised/cobrass/src/assistant"
+	"github.com/snivilised/cobrass/src/clif"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/spf13/cobra"
+
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var profilesShortFlags = cobrass.KnownByCollection{
+	"resolved": "r",
+}
+
+const (
+	profilesShowPsName = "profiles-show-ps"
+
+	// anyExtension denotes the default flags of a profile, which apply
+	// to all extensions without a section of their own.
+	anyExtension = "*"
+)
+
+func newProfilesFlagInfoWithShort[T any](usage string, defaultValue T) *assistant.FlagInfo {
+	name := strings.Split(usage, " ")[0]
+	short := profilesShortFlags[name]
+
+	return assistant.NewFlagInfo(usage, short, defaultValue)
+}
+
+type profilesParameterSetPtr = *assistant.ParamSet[common.ProfilesParameterSet]
+
+func (b *Bootstrap) buildProfilesCommand(container *assistant.CobraContainer) *cobra.Command {
+	profilesCommand := &cobra.Command{
+		Use: common.Definitions.Commands.Profiles,
+		Short: locale.LeadsWith(
+			common.Definitions.Commands.Profiles,
+			xi18n.Text(locale.ProfilesCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.ProfilesLongDefinitionTemplData{}),
+	}
+
+	container.MustRegisterRootedCommand(profilesCommand)
+
+	// the container identifies commands by name, but the sub commands of
+	// profiles share their names with those of other commands, so they are
+	// added to their parent directly.
+	//
+	profilesCommand.AddCommand(
+		b.buildProfilesListCommand(),
+		b.buildProfilesShowCommand(container),
+		b.buildProfilesValidateCommand(),
+	)
+
+	return profilesCommand
+}
+
+func (b *Bootstrap) buildProfilesListCommand() *cobra.Command {
+	listCommand := &cobra.Command{
+		Use: "list",
+		Short: locale.LeadsWith(
+			"list",
+			xi18n.Text(locale.ProfilesListCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return b.tabulateProfiles(cmd.OutOrStdout(), b.Configs.Profiles.Names())
+		},
+	}
+
+	return listCommand
+}
+
+func (b *Bootstrap) buildProfilesShowCommand(container *assistant.CobraContainer) *cobra.Command {
+	showCommand := &cobra.Command{
+		Use: "show",
+		Short: locale.LeadsWith(
+			"show",
+			xi18n.Text(locale.ProfilesShowCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			showPS := container.MustGetParamSet(profilesShowPsName).(profilesParameterSetPtr) //nolint:errcheck // is Must call
+
+			if err := showPS.Validate(); err != nil {
+				return err
+			}
+
+			name := args[0]
+			lookup := b.Configs.Profiles.Declared
+
+			if showPS.Native.Resolved {
+				lookup = b.Configs.Profiles.Resolved
+			}
+
+			spec, found := lookup(name)
+			if !found {
+				return fmt.Errorf("no such profile: '%v'", name)
+			}
+
+			if err := cfg.PrintProfile(cmd.OutOrStdout(), name, spec); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout())
+
+			return b.tabulateProfiles(cmd.OutOrStdout(), []string{name})
+		},
+	}
+
+	paramSet := assistant.NewParamSet[common.ProfilesParameterSet](showCommand)
+
+	// --resolved(r)
+	//
+	const (
+		defaultResolved = false
+	)
+
+	paramSet.BindBool(
+		newProfilesFlagInfoWithShort(
+			xi18n.Text(locale.ProfilesShowCmdResolvedParamUsageTemplData{}),
+			defaultResolved,
+		),
+		&paramSet.Native.Resolved,
+	)
+
+	container.MustRegisterParamSet(profilesShowPsName, paramSet)
+
+	return showCommand
+}
+
+func (b *Bootstrap) buildProfilesValidateCommand() *cobra.Command {
+	validateCommand := &cobra.Command{
+		Use: "validate",
+		Short: locale.LeadsWith(
+			"validate",
+			xi18n.Text(locale.ProfilesValidateCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		// the profiles are validated when the config is loaded, so an
+		// invalid profile would otherwise prevent this command from
+		// running at all. As long as the config could be decoded, each
+		// profile is reported, followed by any other reason the config
+		// is invalid.
+		//
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				invalid locale.InvalidConfigErrorBehaviourQuery
+			)
+
+			if errors.As(b.err, &invalid) && b.Configs != nil {
+				cmd.SilenceUsage = true
+
+				return nil
+			}
+
+			return b.prepare(cmd, args)
+		},
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if err := reportValidity(cmd.OutOrStdout(), "profile",
+				b.Configs.Profiles.Names(), b.Configs.Profiles.Validate,
+			); err != nil {
+				return err
+			}
+
+			return b.err
+		},
+	}
+
+	return validateCommand
+}
+
+// tabulateProfiles writes the command line produced by each of the
+// profiles; one row for the default flags and another for each extension
+// that has a section of its own.
+func (b *Bootstrap) tabulateProfiles(w io.Writer, names []string) error {
+	const (
+		minWidth = 0
+		tabWidth = 4
+		padding  = 2
+	)
+
+	tw := tabwriter.NewWriter(w, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(tw, "PROFILE\tEXTENDS\tEXTENSION\tCOMMAND LINE")
+
+	for _, name := range names {
+		declared, _ := b.Configs.Profiles.Declared(name)
+		resolved, _ := b.Configs.Profiles.Resolved(name)
+		extends := strings.Join(declared.Extends, ",")
+		extensions := lo.Keys(resolved.Overrides)
+		slices.Sort(extensions)
+
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n",
+			name, extends, anyExtension, b.commandLine(resolved.Flags),
+		)
+
+		for _, extension := range extensions {
+			flags, _ := b.Configs.Profiles.ProfileFor(name, extension)
+
+			fmt.Fprintf(tw, "%v\t%v\t%v\t%v\n",
+				name, extends, extension, b.commandLine(flags),
+			)
+		}
+	}
+
+	return tw.Flush()
+}
+
+// commandLine returns the command line the third party program would be
+// invoked with for the flags, with placeholders for the source and
+// destination.
+func (b *Bootstrap) commandLine(flags clif.ChangedFlagsMap) string {
+	cl := cobrass.Evaluate(
+		common.WithoutProfileKeys(flags), common.ThirdPartyFlags, clif.ThirdPartyCommandLine{},
+	)
+
+	return strings.Join(clif.Expand(
+		[]string{b.Configs.Advanced.Executable().Symbol(), "<source>"}, cl, "<destination>",
+	), " ")
+}
+
+// reportValidity writes the outcome of validating each of the named entities and
+// returns an error if any of them is invalid.
+func reportValidity(w io.Writer, entity string, names []string, validate func(name string) error) error {
+	invalid := 0
+
+	for _, name := range names {
+		if err := validate(name); err != nil {
+			invalid++
+
+			fmt.Fprintf(w, "❌ %v: '%v' (%v)\n", entity, name, err)
+
+			continue
+		}
+
+		fmt.Fprintf(w, "✅ %v: '%v'\n", entity, name)
+	}
+
+	if invalid > 0 {
+		return fmt.Errorf("found %v invalid %v(s) out of %v", invalid, entity, len(names))
+	}
+
+	return nil
+}
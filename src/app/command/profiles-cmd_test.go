@@ -0,0 +1,53 @@
+package command_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var _ = Describe("ProfilesCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	DescribeTable("sub commands",
+		func(args []string, shouldFail bool) {
+			_, err := fixture.execute(args...)
+
+			if shouldFail {
+				Expect(err).Error().NotTo(BeNil())
+			} else {
+				Expect(err).Error().To(BeNil())
+			}
+		},
+		func(args []string, shouldFail bool) string {
+			return fmt.Sprintf("🧪 ===> args: '%v', should fail: '%v'", args, shouldFail)
+		},
+		Entry(nil, []string{"profiles", "list"}, false),
+		Entry(nil, []string{"profiles", "show", "adaptive"}, false),
+		Entry(nil, []string{"profiles", "show", "adaptive", "--resolved"}, false),
+		Entry(nil, []string{"profiles", "show", "sf", "-r"}, false),
+		Entry(nil, []string{"profiles", "show", "missing"}, true),
+		Entry(nil, []string{"profiles", "validate"}, false),
+	)
+
+	When("config contains invalid profile", func() {
+		invalid := common.Definitions.Pixa.ConfigTestFilename + "-invalid"
+
+		It("🧪 should: report invalid profile from validate", func() {
+			output, err := fixture.executeWith(invalid, "profiles", "validate")
+
+			Expect(output).To(ContainSubstring("❌ profile: 'blur'"))
+			Expect(err).To(MatchError(ContainSubstring("1 invalid profile(s)")))
+		})
+
+		It("🧪 should: fail other sub commands with invalid config error", func() {
+			_, err := fixture.executeWith(invalid, "profiles", "list")
+
+			Expect(err).To(BeAssignableToTypeOf(locale.InvalidConfigError{}))
+		})
+	})
+})
@@ -0,0 +1,195 @@
+package command
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/snivilised/cobrass"
+	"github.com/snivilised/cobrass/src/assistant"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var recommendShortFlags = cobrass.KnownByCollection{
+	"output": "o",
+	"apply":  "a",
+}
+
+const (
+	recommendPsName = "recommend-ps"
+)
+
+func newRecommendFlagInfoWithShort[T any](usage string, defaultValue T) *assistant.FlagInfo {
+	name := strings.Split(usage, " ")[0]
+	short := recommendShortFlags[name]
+
+	return assistant.NewFlagInfo(usage, short, defaultValue)
+}
+
+type recommendParameterSetPtr = *assistant.ParamSet[common.RecommendParameterSet]
+
+func (b *Bootstrap) buildRecommendCommand(container *assistant.CobraContainer) *cobra.Command {
+	recommendCommand := &cobra.Command{
+		Use: "recommend",
+		Short: locale.LeadsWith(
+			"recommend",
+			xi18n.Text(locale.RecommendCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.RecommendLongDefinitionTemplData{}),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			recommendPS := container.MustGetParamSet(recommendPsName).(recommendParameterSetPtr) //nolint:errcheck // is Must call
+
+			if err := recommendPS.Validate(); err != nil {
+				return err
+			}
+
+			b.Logger.Info(
+				fmt.Sprintf("%v %v running recommend",
+					common.Definitions.Pixa.AppName, common.Definitions.Pixa.Emoji,
+				),
+				slog.String("args", strings.Join(args, "/")),
+			)
+
+			inputs := b.getRecommendInputs()
+			inputs.Root.ParamSet.Native.Directory = utils.ResolvePath(args[0])
+
+			// there is nothing to recommend unless there are multiple
+			// profiles to choose between.
+			//
+			scheme := inputs.Root.ProfileFam.Native.Scheme
+			if scheme == "" {
+				return locale.NewSchemeRequiredError(common.Definitions.Commands.Recommend)
+			}
+
+			if err := b.Configs.Schemes.Validate(scheme, b.Configs.Profiles); err != nil {
+				return err
+			}
+
+			flagSet := cmd.Flags()
+
+			fallback(flagSet, "no-files",
+				&inputs.Root.ParamSet.Native.NoFiles, b.Configs.Sampler.NoFiles(),
+			)
+			fallback(flagSet, "no-folders",
+				&inputs.Root.ParamSet.Native.NoFolders, b.Configs.Sampler.NoFolders(),
+			)
+
+			if path := recommendPS.Native.OutputPath; path != "" {
+				recommendPS.Native.OutputPath = utils.ResolvePath(path)
+			}
+
+			standings, err := proxy.EnterRecommend(
+				&proxy.RecommendParams{
+					Inputs:        inputs,
+					Viper:         b.OptionsInfo.Config.Viper,
+					Logger:        b.Logger,
+					Vfs:           b.Vfs,
+					Notifications: &b.Notifications,
+				},
+			)
+
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout())
+
+			if err := report.Tabulate(cmd.OutOrStdout(), standings); err != nil {
+				return err
+			}
+
+			if recommendPS.Native.Apply {
+				return b.applyRecommendation(cmd, standings)
+			}
+
+			return nil
+		},
+	}
+
+	paramSet := assistant.NewParamSet[common.RecommendParameterSet](recommendCommand)
+
+	// --output(o)
+	//
+	const (
+		defaultOutputPath = ""
+	)
+
+	paramSet.BindString(
+		newRecommendFlagInfoWithShort(
+			xi18n.Text(locale.RecommendCmdOutputPathParamUsageTemplData{}),
+			defaultOutputPath,
+		),
+		&paramSet.Native.OutputPath,
+	)
+
+	// --apply(a)
+	//
+	const (
+		defaultApply = false
+	)
+
+	paramSet.BindBool(
+		newRecommendFlagInfoWithShort(
+			xi18n.Text(locale.RecommendCmdApplyParamUsageTemplData{}),
+			defaultApply,
+		),
+		&paramSet.Native.Apply,
+	)
+
+	container.MustRegisterRootedCommand(recommendCommand)
+	container.MustRegisterParamSet(recommendPsName, paramSet)
+
+	return recommendCommand
+}
+
+// applyRecommendation writes the winning profile into the config file in
+// use as the default profile. A profile that did not produce a single
+// measurable result is not a winner, so nothing is written.
+func (b *Bootstrap) applyRecommendation(cmd *cobra.Command, standings []*report.Standing) error {
+	if len(standings) == 0 || standings[0].Score() <= 0 {
+		return locale.NewNoRecommendationError()
+	}
+
+	path := b.OptionsInfo.Config.Viper.ConfigFileUsed()
+	if path == "" {
+		return locale.NewNoConfigFileError()
+	}
+
+	winner := standings[0].Profile
+
+	if err := cfg.SetDefaultProfile(b.Vfs, path, winner); err != nil {
+		return err
+	}
+
+	b.Logger.Info("✅ applied recommendation",
+		slog.String("profile", winner),
+		slog.String("config", path),
+	)
+	fmt.Fprintf(cmd.OutOrStdout(), "\n✅ default profile set to '%v' in '%v'\n", winner, path)
+
+	return nil
+}
+
+func (b *Bootstrap) getRecommendInputs() *common.RecommendCommandInputs {
+	root := b.getRootInputs()
+	shrink := b.getShrinkInputs()
+	shrink.Root = root
+
+	return &common.RecommendCommandInputs{
+		Root: root,
+		ParamSet: b.Container.MustGetParamSet(
+			recommendPsName,
+		).(*assistant.ParamSet[common.RecommendParameterSet]),
+		Shrink: shrink,
+	}
+}
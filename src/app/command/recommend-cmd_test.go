@@ -0,0 +1,35 @@
+package command_test
+
+import (
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/internal/helpers"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var _ = Describe("RecommendCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	When("scheme is specified", func() {
+		It("🧪 should: rank profiles without error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+			_, err := fixture.execute(
+				common.Definitions.Commands.Recommend, directory,
+				"--scheme", "blur-sf", "--no-tui",
+			)
+			Expect(err).Error().To(BeNil())
+		})
+	})
+
+	When("scheme is not specified", func() {
+		It("🧪 should: return error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+			_, err := fixture.execute(
+				common.Definitions.Commands.Recommend, directory,
+			)
+			Expect(err).To(BeAssignableToTypeOf(locale.SchemeRequiredError{}))
+		})
+	})
+})
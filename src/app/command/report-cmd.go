@@ -0,0 +1,134 @@
+package command
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/snivilised/cobrass"
+	"github.com/snivilised/cobrass/src/assistant"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/snivilised/extendio/xfs/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/snivilised/pixa/src/app/proxy"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var reportShortFlags = cobrass.KnownByCollection{
+	"output":      "o",
+	"report-file": "r",
+}
+
+const (
+	reportPsName = "report-ps"
+)
+
+func newReportFlagInfoWithShort[T any](usage string, defaultValue T) *assistant.FlagInfo {
+	name := strings.Split(usage, " ")[0]
+	short := reportShortFlags[name]
+
+	return assistant.NewFlagInfo(usage, short, defaultValue)
+}
+
+type reportParameterSetPtr = *assistant.ParamSet[common.ReportParameterSet]
+
+func (b *Bootstrap) buildReportCommand(container *assistant.CobraContainer) *cobra.Command {
+	reportCommand := &cobra.Command{
+		Use: "report",
+		Short: locale.LeadsWith(
+			"report",
+			xi18n.Text(locale.ReportCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.ReportLongDefinitionTemplData{}),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(_ *cobra.Command, args []string) error {
+			reportPS := container.MustGetParamSet(reportPsName).(reportParameterSetPtr) //nolint:errcheck // is Must call
+
+			if err := reportPS.Validate(); err != nil {
+				return err
+			}
+
+			b.Logger.Info(
+				fmt.Sprintf("%v %v running report",
+					common.Definitions.Pixa.AppName, common.Definitions.Pixa.Emoji,
+				),
+				slog.String("args", strings.Join(args, "/")),
+			)
+
+			inputs := b.getReportInputs()
+			inputs.Root.ParamSet.Native.Directory = utils.ResolvePath(args[0])
+
+			if scheme := inputs.Root.ProfileFam.Native.Scheme; scheme != "" {
+				if err := b.Configs.Schemes.Validate(scheme, b.Configs.Profiles); err != nil {
+					return err
+				}
+			}
+
+			if path := reportPS.Native.OutputPath; path != "" {
+				reportPS.Native.OutputPath = utils.ResolvePath(path)
+			}
+
+			if path := reportPS.Native.ReportPath; path != "" {
+				reportPS.Native.ReportPath = utils.ResolvePath(path)
+			}
+
+			_, err := proxy.EnterReport(
+				&proxy.ReportParams{
+					Inputs: inputs,
+					Viper:  b.OptionsInfo.Config.Viper,
+					Logger: b.Logger,
+					Vfs:    b.Vfs,
+				},
+			)
+
+			return err
+		},
+	}
+
+	paramSet := assistant.NewParamSet[common.ReportParameterSet](reportCommand)
+
+	// --output(o)
+	//
+	const (
+		defaultOutputPath = ""
+	)
+
+	paramSet.BindString(
+		newReportFlagInfoWithShort(
+			xi18n.Text(locale.ReportCmdOutputPathParamUsageTemplData{}),
+			defaultOutputPath,
+		),
+		&paramSet.Native.OutputPath,
+	)
+
+	// --report-file(r)
+	//
+	const (
+		defaultReportPath = ""
+	)
+
+	paramSet.BindString(
+		newReportFlagInfoWithShort(
+			xi18n.Text(locale.ReportCmdReportFileParamUsageTemplData{}),
+			defaultReportPath,
+		),
+		&paramSet.Native.ReportPath,
+	)
+
+	container.MustRegisterRootedCommand(reportCommand)
+	container.MustRegisterParamSet(reportPsName, paramSet)
+
+	return reportCommand
+}
+
+func (b *Bootstrap) getReportInputs() *common.ReportCommandInputs {
+	return &common.ReportCommandInputs{
+		Root: b.getRootInputs(),
+		ParamSet: b.Container.MustGetParamSet(
+			reportPsName,
+		).(*assistant.ParamSet[common.ReportParameterSet]),
+	}
+}
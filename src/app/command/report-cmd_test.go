@@ -0,0 +1,84 @@
+package command_test
+
+import (
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/internal/helpers"
+	"github.com/snivilised/pixa/src/internal/matchers"
+)
+
+var _ = Describe("ReportCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	When("directory contains no results", func() {
+		It("🧪 should: write report without error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+			reportFile := filepath.Join(fixture.root, "report.html")
+			_, err := fixture.execute(
+				common.Definitions.Commands.Report, directory,
+				"--scheme", "blur-sf", "--report-file", reportFile,
+			)
+			Expect(err).Error().To(BeNil())
+			Expect(matchers.AsFile(reportFile)).To(matchers.ExistInFS(fixture.vfs))
+		})
+	})
+
+	When("sample requested", func() {
+		It("🧪 should: find sampled results", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+			reportFile := filepath.Join(fixture.root, "report.html")
+			entries, err := fixture.vfs.ReadDir(directory)
+			Expect(err).To(Succeed())
+
+			input := ""
+			for _, entry := range entries {
+				if filepath.Ext(entry.Name()) == ".jpg" {
+					input = entry.Name()
+					break
+				}
+			}
+			Expect(input).NotTo(BeEmpty())
+
+			// sampled results are written alongside their input, decorated
+			// with the sample label (SAMPLE in the test config)
+			//
+			results := []string{}
+			for _, profile := range []string{"blur", "sf"} {
+				result := strings.TrimSuffix(input, ".jpg") + ".$SAMPLE$.blur-sf." + profile + ".jpg"
+				results = append(results, result)
+				Expect(fixture.vfs.WriteFile(filepath.Join(directory, result),
+					[]byte{}, common.Permissions.Beezledub),
+				).To(Succeed())
+			}
+
+			_, err = fixture.execute(
+				common.Definitions.Commands.Report, directory,
+				"--scheme", "blur-sf", "--sample", "--report-file", reportFile,
+			)
+			Expect(err).Error().To(BeNil())
+
+			content, err := fixture.vfs.ReadFile(reportFile)
+			Expect(err).To(Succeed())
+
+			for _, result := range results {
+				Expect(string(content)).To(ContainSubstring(result))
+			}
+		})
+	})
+
+	When("scheme is not defined", func() {
+		It("🧪 should: return error", func() {
+			directory := helpers.Path(fixture.root, BackyardWorldsPlanet9Scan01)
+			_, err := fixture.execute(
+				common.Definitions.Commands.Report, directory,
+				"--scheme", "non-existent",
+			)
+			Expect(err).Error().NotTo(BeNil())
+		})
+	})
+})
@@ -4,10 +4,14 @@ Copyright © 2022 NAME HERE <EMAIL ADDRESS>
 package command
 
 import (
+	"strconv"
+
 	"github.com/snivilised/cobrass/src/assistant"
 	"github.com/snivilised/cobrass/src/store"
+	xi18n "github.com/snivilised/extendio/i18n"
 	"github.com/snivilised/extendio/xfs/storage"
 	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
 )
 
 const (
@@ -46,6 +50,17 @@ func (b *Bootstrap) buildRootCommand(container *assistant.CobraContainer) {
 	previewFam := assistant.NewParamSet[store.PreviewParameterSet](rootCommand)
 	previewFam.Native.BindAll(previewFam, rootCommand.PersistentFlags())
 
+	// --dry-run is a bool flag that also accepts simulate; without a value
+	// it remains a regular dry run.
+	//
+	if flag := rootCommand.PersistentFlags().Lookup("dry-run"); flag != nil {
+		flag.Value = &dryRunValue{
+			dryRun:   &previewFam.Native.DryRun,
+			simulate: &paramSet.Native.Simulate,
+		}
+		flag.Usage = xi18n.Text(locale.RootCmdDryRunParamUsageTemplData{})
+	}
+
 	// family: worker-pool [--cpu(C), --now(N)]
 	//
 	workerPoolFam := assistant.NewParamSet[store.WorkerPoolParameterSet](rootCommand)
@@ -115,3 +130,41 @@ func (b *Bootstrap) getRootInputs() *common.RootCommandInputs {
 		Observers:    &b.Observers,
 	}
 }
+
+const simulateDryRun = "simulate"
+
+// dryRunValue is the pflag.Value of --dry-run, which in addition to the
+// usual bool values, accepts simulate.
+type dryRunValue struct {
+	dryRun   *bool
+	simulate *bool
+}
+
+func (v *dryRunValue) Set(value string) error {
+	if value == simulateDryRun {
+		*v.dryRun, *v.simulate = true, true
+
+		return nil
+	}
+
+	dry, err := strconv.ParseBool(value)
+	if err != nil {
+		return locale.NewInvalidDryRunError(value, "true, false, "+simulateDryRun)
+	}
+
+	*v.dryRun, *v.simulate = dry, false
+
+	return nil
+}
+
+func (v *dryRunValue) String() string {
+	if *v.simulate {
+		return simulateDryRun
+	}
+
+	return strconv.FormatBool(*v.dryRun)
+}
+
+func (v *dryRunValue) Type() string {
+	return "bool"
+}
@@ -0,0 +1,121 @@
+package command
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/snivilised/cobrass/src/assistant"
+	xi18n "github.com/snivilised/extendio/i18n"
+	"github.com/spf13/cobra"
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+func (b *Bootstrap) buildSchemesCommand(container *assistant.CobraContainer) *cobra.Command {
+	schemesCommand := &cobra.Command{
+		Use: common.Definitions.Commands.Schemes,
+		Short: locale.LeadsWith(
+			common.Definitions.Commands.Schemes,
+			xi18n.Text(locale.SchemesCmdShortDefinitionTemplData{}),
+		),
+		Long: xi18n.Text(locale.SchemesLongDefinitionTemplData{}),
+	}
+
+	container.MustRegisterRootedCommand(schemesCommand)
+
+	// the container identifies commands by name, but the sub commands of
+	// schemes share their names with those of other commands, so they are
+	// added to their parent directly.
+	//
+	schemesCommand.AddCommand(
+		b.buildSchemesListCommand(),
+		b.buildSchemesShowCommand(),
+		b.buildSchemesValidateCommand(),
+	)
+
+	return schemesCommand
+}
+
+func (b *Bootstrap) buildSchemesListCommand() *cobra.Command {
+	listCommand := &cobra.Command{
+		Use: "list",
+		Short: locale.LeadsWith(
+			"list",
+			xi18n.Text(locale.SchemesListCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			const (
+				minWidth = 0
+				tabWidth = 4
+				padding  = 2
+			)
+
+			tw := tabwriter.NewWriter(cmd.OutOrStdout(), minWidth, tabWidth, padding, ' ', 0)
+
+			fmt.Fprintln(tw, "SCHEME\tPROFILES")
+
+			for _, name := range b.Configs.Schemes.Names() {
+				scheme, _ := b.Configs.Schemes.Scheme(name)
+
+				fmt.Fprintf(tw, "%v\t%v\n", name, strings.Join(scheme.Profiles(), ","))
+			}
+
+			return tw.Flush()
+		},
+	}
+
+	return listCommand
+}
+
+func (b *Bootstrap) buildSchemesShowCommand() *cobra.Command {
+	showCommand := &cobra.Command{
+		Use: "show",
+		Short: locale.LeadsWith(
+			"show",
+			xi18n.Text(locale.SchemesShowCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.ExactArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			if err := b.Configs.Schemes.Validate(name, b.Configs.Profiles); err != nil {
+				return err
+			}
+
+			scheme, _ := b.Configs.Schemes.Scheme(name)
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%v: [%v]\n\n", name, strings.Join(scheme.Profiles(), ", "))
+
+			return b.tabulateProfiles(cmd.OutOrStdout(), scheme.Profiles())
+		},
+	}
+
+	return showCommand
+}
+
+func (b *Bootstrap) buildSchemesValidateCommand() *cobra.Command {
+	validateCommand := &cobra.Command{
+		Use: "validate",
+		Short: locale.LeadsWith(
+			"validate",
+			xi18n.Text(locale.SchemesValidateCmdShortDefinitionTemplData{}),
+		),
+		Args: cobra.NoArgs,
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return reportValidity(cmd.OutOrStdout(), "scheme",
+				b.Configs.Schemes.Names(),
+				func(name string) error {
+					return b.Configs.Schemes.Validate(name, b.Configs.Profiles)
+				},
+			)
+		},
+	}
+
+	return validateCommand
+}
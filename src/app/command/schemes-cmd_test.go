@@ -0,0 +1,31 @@
+package command_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+)
+
+var _ = Describe("SchemesCmd", Ordered, func() {
+	fixture := useCommandFixture()
+
+	DescribeTable("sub commands",
+		func(args []string, shouldFail bool) {
+			_, err := fixture.execute(args...)
+
+			if shouldFail {
+				Expect(err).Error().NotTo(BeNil())
+			} else {
+				Expect(err).Error().To(BeNil())
+			}
+		},
+		func(args []string, shouldFail bool) string {
+			return fmt.Sprintf("🧪 ===> args: '%v', should fail: '%v'", args, shouldFail)
+		},
+		Entry(nil, []string{"schemes", "list"}, false),
+		Entry(nil, []string{"schemes", "show", "adaptive-sf"}, false),
+		Entry(nil, []string{"schemes", "show", "missing"}, true),
+		Entry(nil, []string{"schemes", "validate"}, false),
+	)
+})
@@ -32,16 +32,6 @@ import (
 // compound filter. If files filter was not compound, it would be named
 // file and the short forms would be x and g instead of X and G.
 
-var thirdPartyFlags = cobrass.KnownByCollection{
-	// third-party: (perhaps third party parameters should not have short codes)
-	//
-	"gaussian-blur":   "b",
-	"sampling-factor": "f",
-	"interlace":       "i",
-	"strip":           "s",
-	"quality":         "q",
-}
-
 var shrinkShortFlags = cobrass.KnownByCollection{
 	// shrink specific:
 	//
@@ -63,7 +53,7 @@ var shrinkShortFlags = cobrass.KnownByCollection{
 }
 
 func init() {
-	maps.Copy(shrinkShortFlags, thirdPartyFlags)
+	maps.Copy(shrinkShortFlags, common.ThirdPartyFlags)
 }
 
 const (
@@ -97,9 +87,14 @@ func (b *Bootstrap) buildShrinkCommand(container *assistant.CobraContainer) *cob
 			if validationErr := shrinkPS.Validate(); validationErr == nil {
 				// optionally invoke cross field validation
 				//
-				if xvErr := shrinkPS.CrossValidate(func(_ *common.ShrinkParameterSet) error {
-					// cross validation not currently required
+				if xvErr := shrinkPS.CrossValidate(func(ps *common.ShrinkParameterSet) error {
+					// flattening only applies to the results being diverted
+					// to the output
 					//
+					if ps.Flatten && ps.OutputPath == "" {
+						return locale.NewFlattenRequiresOutputError()
+					}
+
 					return nil
 				}); xvErr == nil {
 					flagSet := cmd.Flags()
@@ -125,19 +120,48 @@ func (b *Bootstrap) buildShrinkCommand(container *assistant.CobraContainer) *cob
 					inputs.Root.ParamSet.Native.Directory = utils.ResolvePath(args[0])
 
 					// Apply fallbacks, ie user didn't specify flag on command line
-					// so fallback to one defined in config. This is supposed to
-					// work transparently with Viper, but this doesn't work with
-					// custom locations; ie no-files is defined under sampler, but
-					// viper would expect to see it at the root. Even so, still found
-					// that viper would fail to pick up this value, so implementing
-					// the fall back manually here.
+					// so fallback to one defined in config.
 					//
 					if inputs.Root.ParamSet.Native.IsSampling {
-						if !flagSet.Changed("no-files") && b.Configs.Sampler.NoFiles() > 0 {
-							inputs.Root.ParamSet.Native.NoFiles = b.Configs.Sampler.NoFiles()
+						fallback(flagSet, "no-files",
+							&inputs.Root.ParamSet.Native.NoFiles, b.Configs.Sampler.NoFiles(),
+						)
+					}
+
+					if profile := b.Configs.Defaults.Profile(); profile != "" {
+						if inputs.Root.ProfileFam.Native.Profile == "" &&
+							inputs.Root.ProfileFam.Native.Scheme == "" {
+							inputs.Root.ProfileFam.Native.Profile = profile
 						}
 					}
 
+					fallback(flagSet, "min-ssim",
+						&inputs.ParamSet.Native.MinSSIM, b.Configs.Advanced.Quality().MinSSIM(),
+					)
+					fallback(flagSet, "error-policy",
+						&inputs.ParamSet.Native.ErrorPolicyEn.Source, b.Configs.Advanced.ErrorPolicy(),
+					)
+					fallback(flagSet, "max-failures",
+						&inputs.ParamSet.Native.MaxFailures, b.Configs.Advanced.MaxFailures(),
+					)
+
+					if path := inputs.ParamSet.Native.HTMLReport; path != "" {
+						inputs.ParamSet.Native.HTMLReport = utils.ResolvePath(path)
+					}
+
+					if path := inputs.ParamSet.Native.PlanFile; path != "" {
+						inputs.ParamSet.Native.PlanFile = utils.ResolvePath(path)
+					}
+
+					if path := inputs.ParamSet.Native.Plan; path != "" {
+						// nothing is done when planning, other than to
+						// discover what would be done.
+						//
+						inputs.ParamSet.Native.Plan = utils.ResolvePath(path)
+						inputs.Root.PreviewFam.Native.DryRun = true
+						inputs.Root.ParamSet.Native.Simulate = false
+					}
+
 					_, appErr = proxy.EnterShrink(
 						&proxy.ShrinkParams{
 							Inputs:        inputs,
@@ -145,6 +169,8 @@ func (b *Bootstrap) buildShrinkCommand(container *assistant.CobraContainer) *cob
 							Logger:        b.Logger,
 							Vfs:           b.Vfs,
 							Notifications: &b.Notifications,
+							Version:       Version,
+							CachePath:     b.dataPath(common.Definitions.Defaults.Cache.Filename),
 						},
 					)
 				} else {
@@ -218,6 +244,183 @@ func (b *Bootstrap) buildShrinkCommand(container *assistant.CobraContainer) *cob
 		&paramSet.Native.Cuddle,
 	)
 
+	// --flatten
+	//
+	const (
+		defaultFlatten = false
+	)
+
+	paramSet.BindBool(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdFlattenParamUsageTemplData{}),
+			defaultFlatten,
+		),
+		&paramSet.Native.Flatten,
+	)
+
+	// --incremental
+	//
+	const (
+		defaultIncremental = false
+	)
+
+	paramSet.BindBool(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdIncrementalParamUsageTemplData{}),
+			defaultIncremental,
+		),
+		&paramSet.Native.Incremental,
+	)
+
+	// --force
+	//
+	const (
+		defaultForce = false
+	)
+
+	paramSet.BindBool(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdForceParamUsageTemplData{}),
+			defaultForce,
+		),
+		&paramSet.Native.Force,
+	)
+
+	// --link
+	//
+	const (
+		defaultLink = false
+	)
+
+	paramSet.BindBool(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdLinkParamUsageTemplData{}),
+			defaultLink,
+		),
+		&paramSet.Native.Link,
+	)
+
+	// --html-report
+	//
+	const (
+		defaultHTMLReport = ""
+	)
+
+	paramSet.BindString(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdHTMLReportParamUsageTemplData{}),
+			defaultHTMLReport,
+		),
+		&paramSet.Native.HTMLReport,
+	)
+
+	// --min-ssim
+	//
+	const (
+		defaultMinSSIM = float64(0)
+		minMinSSIM     = float64(0)
+		maxMinSSIM     = float64(1)
+	)
+
+	paramSet.BindValidatedFloat64Within(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdMinSSIMParamUsageTemplData{}),
+			defaultMinSSIM,
+		),
+		&paramSet.Native.MinSSIM,
+		minMinSSIM,
+		maxMinSSIM,
+	)
+
+	// --target-size
+	//
+	const (
+		defaultTargetSize = ""
+	)
+
+	paramSet.BindValidatedString(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdTargetSizeParamUsageTemplData{}),
+			defaultTargetSize,
+		),
+		&paramSet.Native.TargetSize, func(s string, f *pflag.Flag) error {
+			if f.Changed {
+				if _, err := common.ParseByteSize(s); err != nil {
+					return locale.NewInvalidTargetSizeError(s)
+				}
+			}
+
+			return nil
+		},
+	)
+
+	// --error-policy
+	//
+	const (
+		defaultErrorPolicy = "continue"
+	)
+
+	paramSet.Native.ErrorPolicyEn = common.ErrorPolicyEnumInfo.NewValue()
+
+	paramSet.BindValidatedEnum(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdErrorPolicyParamUsageTemplData{}),
+			defaultErrorPolicy,
+		),
+		&paramSet.Native.ErrorPolicyEn.Source,
+		func(value string, f *pflag.Flag) error {
+			if f.Changed && !(common.ErrorPolicyEnumInfo.IsValid(value)) {
+				acceptableSet := common.ErrorPolicyEnumInfo.AcceptablePrimes()
+
+				return locale.NewInvalidErrorPolicyError(value, acceptableSet)
+			}
+
+			return nil
+		},
+	)
+
+	// --max-failures
+	//
+	const (
+		defaultMaxFailures = uint(0)
+	)
+
+	paramSet.BindUint(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdMaxFailuresParamUsageTemplData{}),
+			defaultMaxFailures,
+		),
+		&paramSet.Native.MaxFailures,
+	)
+
+	// --plan-file
+	//
+	const (
+		defaultPlanFile = ""
+	)
+
+	paramSet.BindString(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdPlanFileParamUsageTemplData{}),
+			defaultPlanFile,
+		),
+		&paramSet.Native.PlanFile,
+	)
+
+	// --plan
+	//
+	const (
+		defaultPlan = ""
+	)
+
+	paramSet.BindString(
+		newShrinkFlagInfoWithShort(
+			xi18n.Text(locale.ShrinkCmdPlanParamUsageTemplData{}),
+			defaultPlan,
+		),
+		&paramSet.Native.Plan,
+	)
+
 	// --gaussian-blur(b)
 	//
 	const (
@@ -326,7 +529,7 @@ func (b *Bootstrap) buildShrinkCommand(container *assistant.CobraContainer) *cob
 	polyFam := assistant.NewParamSet[store.PolyFilterParameterSet](shrinkCommand)
 	polyFam.Native.BindAll(polyFam)
 
-	paramSet.Native.KnownBy = thirdPartyFlags
+	paramSet.Native.KnownBy = common.ThirdPartyFlags
 
 	// 📌 A note about cobra args validation: cmd.ValidArgs lets you define
 	// a list of all allowable tokens for positional args. Just define
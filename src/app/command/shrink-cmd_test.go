@@ -270,6 +270,25 @@ var _ = Describe("ShrinkCmd", Ordered, func() {
 				},
 			},
 		}),
+
+		Entry(nil, &shrinkTE{
+			commandTE: commandTE{
+				message: "target size",
+				args: []string{
+					"--target-size", "500KB",
+				},
+			},
+		}),
+
+		Entry(nil, &shrinkTE{
+			commandTE: commandTE{
+				message:     "expect error since target size is not a size",
+				expectError: true,
+				args: []string{
+					"--target-size", "big",
+				},
+			},
+		}),
 		// <----
 	)
 
@@ -4,8 +4,14 @@ Copyright © 2023 NAME HERE <EMAIL ADDRESS>
 */
 package main
 
-import "github.com/snivilised/pixa/src/app/command"
+import (
+	"os"
+
+	"github.com/snivilised/pixa/src/app/command"
+)
 
 func main() {
-	_ = command.Execute()
+	if err := command.Execute(); err != nil {
+		os.Exit(command.ExitCode(err))
+	}
 }
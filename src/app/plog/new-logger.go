@@ -10,6 +10,7 @@ import (
 	"github.com/snivilised/extendio/xfs/storage"
 	"github.com/snivilised/extendio/xfs/utils"
 	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
 	"go.uber.org/zap"
 	"go.uber.org/zap/exp/zapslog"
 	"go.uber.org/zap/zapcore"
@@ -19,7 +20,11 @@ func New(lc common.LoggingConfig,
 	vfs storage.VirtualFS,
 	scope common.ConfigScope,
 	vc configuration.ViperConfig,
-) *slog.Logger {
+) (*slog.Logger, error) {
+	var (
+		scopeErr error
+	)
+
 	logPath := lo.TernaryF(common.IsUsingXDG(vc),
 		func() string {
 			// manual XDG: ~/.local/share/app/filename.log
@@ -36,18 +41,26 @@ func New(lc common.LoggingConfig,
 				return utils.ResolvePath(lp)
 			}
 
-			dir, _ := scope.LogPath(common.Definitions.Defaults.Logging.LogFilename)
+			dir, err := scope.LogPath(common.Definitions.Defaults.Logging.LogFilename)
+			scopeErr = err
 
 			return dir
 		},
 	)
 
-	logPath, _ = utils.EnsurePathAt(
+	if scopeErr != nil {
+		return nil, locale.NewLogFileError(logPath, scopeErr)
+	}
+
+	logPath, err := utils.EnsurePathAt(
 		logPath,
 		common.Definitions.Defaults.Logging.LogFilename,
 		int(common.Permissions.Write),
 		vfs,
 	)
+	if err != nil {
+		return nil, locale.NewLogFileError(logPath, err)
+	}
 
 	sync := zapcore.AddSync(&lumberjack.Logger{
 		Filename:   logPath,
@@ -63,7 +76,7 @@ func New(lc common.LoggingConfig,
 		level(lc.Level()),
 	)
 
-	return slog.New(zapslog.NewHandler(core, nil))
+	return slog.New(zapslog.NewHandler(core, nil)), nil
 }
 
 func level(raw string) zapcore.LevelEnabler {
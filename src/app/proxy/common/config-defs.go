@@ -29,8 +29,10 @@ type (
 		Profiles    ProfilesConfig
 		Schemes     SchemesConfig
 		Sampler     SamplerConfig
+		Defaults    DefaultsConfig
 		Interaction InteractionConfig
 		Advanced    AdvancedConfig
+		Filing      FilingConfig
 		Logging     LoggingConfig
 	}
 
@@ -47,10 +49,26 @@ type (
 	ConfigRunner interface {
 		Run() error
 		DefaultPath() string
+		SearchPaths() ([]string, error)
+		ScopePath(scope ConfigScopeEnum) (string, error)
+	}
+
+	// ProfileSpec describes a profile; the profiles it extends, the flags
+	// that apply to all extensions and the flags that override them for
+	// particular extensions.
+	ProfileSpec struct {
+		Extends   []string
+		Flags     clif.ChangedFlagsMap
+		Overrides map[string]clif.ChangedFlagsMap
 	}
 
 	ProfilesConfig interface {
 		Profile(name string) (clif.ChangedFlagsMap, bool)
+		ProfileFor(name string, extensions ...string) (clif.ChangedFlagsMap, bool)
+		Declared(name string) (*ProfileSpec, bool)
+		Resolved(name string) (*ProfileSpec, bool)
+		Names() []string
+		Validate(name string) error
 	}
 
 	SchemeConfig interface {
@@ -60,6 +78,7 @@ type (
 	SchemesConfig interface {
 		Validate(name string, profiles ProfilesConfig) error
 		Scheme(name string) (SchemeConfig, bool)
+		Names() []string
 	}
 
 	SamplerConfig interface {
@@ -67,6 +86,12 @@ type (
 		NoFolders() uint
 	}
 
+	// DefaultsConfig defines the values used when the corresponding
+	// flags have not been specified on the command line.
+	DefaultsConfig interface {
+		Profile() string
+	}
+
 	ExtensionsConfig interface {
 		Suffixes() string
 		Transforms() string
@@ -77,6 +102,24 @@ type (
 		Symbol() string
 		ProgramTimeout() (duration time.Duration, err error)
 		NoRetries() uint
+		// ExtraFlags are the flags of the third party program, that may
+		// appear in a profile, in addition to the ThirdPartyFlags.
+		ExtraFlags() []string
+	}
+
+	QualityConfig interface {
+		// Metrics indicates when quality metrics should be computed:
+		// "always", "sample" (only when sampling) or "never"
+		Metrics() string
+		MinSSIM() float64
+	}
+
+	TargetSizeConfig interface {
+		// Tolerance is the fraction of the target size, below the target,
+		// within which a result is deemed to be close enough to stop
+		// searching.
+		Tolerance() float64
+		MaxAttempts() uint
 	}
 
 	TuiConfig interface {
@@ -88,16 +131,38 @@ type (
 	}
 
 	AdvancedConfig interface {
-		AbortOnError() bool
+		// ErrorPolicy determines what happens to the run when an item
+		// fails: "abort", "continue" or "quarantine" (see ErrorPolicyEnum)
+		ErrorPolicy() string
+		// MaxFailures is the number of failed items after which the run
+		// is aborted, regardless of the error policy; 0 denotes no limit.
+		MaxFailures() uint
 		AdhocLabel() string
 		JournalLabel() string
 		LegacyLabel() string
 		TrashLabel() string
+		FailedLabel() string
+		DejaVuLabel() string
 		FakeLabel() string
 		SupplementLabel() string
 		SampleLabel() string
 		Extensions() ExtensionsConfig
 		Executable() ExecutableConfig
+		Quality() QualityConfig
+		TargetSize() TargetSizeConfig
+	}
+
+	// TemplatesConfig contains the templates that override the layout of
+	// the paths created by the path finder (see PathTemplates); an empty
+	// template denotes the built in layout.
+	TemplatesConfig interface {
+		Transfer() string
+		Result() string
+		Transparent() string
+	}
+
+	FilingConfig interface {
+		Templates() TemplatesConfig
 	}
 
 	LoggingConfig interface {
@@ -112,6 +177,7 @@ type (
 	ConfigScope interface {
 		ConfigDirs() ([]string, error)
 		LogPath(filename string) (string, error)
+		DataPath(filename string) (string, error)
 	}
 )
 
@@ -13,7 +13,14 @@ const (
 
 type (
 	commandDefs struct {
-		Shrink string
+		Shrink    string
+		Report    string
+		Recommend string
+		Profiles  string
+		Schemes   string
+		Config    string
+		Apply     string
+		Dupes     string
 	}
 
 	pixaDefs struct {
@@ -33,16 +40,30 @@ type (
 	}
 
 	configDefs struct {
-		ConfigFilename string
+		ConfigFilename  string
+		ProjectFilename string
 	}
 
 	loggingDefs struct {
 		LogFilename string
 	}
 
+	reportDefs struct {
+		Filename string
+		// MappingFilename is the name of the file, in the output, that
+		// relates flattened results back to their originals
+		MappingFilename string
+	}
+
+	cacheDefs struct {
+		Filename string
+	}
+
 	defaultDefs struct {
 		Config  configDefs
 		Logging loggingDefs
+		Report  reportDefs
+		Cache   cacheDefs
 	}
 
 	environmentDefs struct {
@@ -53,6 +74,24 @@ type (
 	filingDefs struct {
 		JournalExt    string
 		Discriminator string // helps to identify files that should be filtered out
+		Attempt       string // label of the temporary file used by target-size
+		ErrorExt      string // suffix of the sidecar of a quarantined file
+		DejaVu        string // name of the marker of a folder created by pixa
+		Manifest      string // name of the sidecar manifest of processed results
+		Xattr         string // name of the extended attribute of a processed result
+	}
+
+	qualityDefs struct {
+		Always string
+		Sample string
+		Never  string
+	}
+
+	// profileKeyDefs are the keys that may appear in a profile that are
+	// interpreted by pixa, rather than being passed on to the third party
+	// program.
+	profileKeyDefs struct {
+		TargetSize string
 	}
 
 	interactionDefs struct {
@@ -69,6 +108,8 @@ type (
 		Defaults    defaultDefs
 		Environment environmentDefs
 		Filing      filingDefs
+		Quality     qualityDefs
+		ProfileKeys profileKeyDefs
 		Interaction interactionDefs
 	}
 )
@@ -89,15 +130,30 @@ var Definitions = definitions{
 		Fake:   "fake",
 	},
 	Commands: commandDefs{
-		Shrink: "shrink",
+		Shrink:    "shrink",
+		Report:    "report",
+		Recommend: "recommend",
+		Profiles:  "profiles",
+		Schemes:   "schemes",
+		Config:    "config",
+		Apply:     "apply",
+		Dupes:     "dupes",
 	},
 	Defaults: defaultDefs{
 		Config: configDefs{
-			ConfigFilename: fmt.Sprintf("%v.%v", appName, yml),
+			ConfigFilename:  fmt.Sprintf("%v.%v", appName, yml),
+			ProjectFilename: fmt.Sprintf(".%v.%v", appName, yml),
 		},
 		Logging: loggingDefs{
 			LogFilename: fmt.Sprintf("%v.log", appName),
 		},
+		Report: reportDefs{
+			Filename:        fmt.Sprintf("%v-report.html", appName),
+			MappingFilename: fmt.Sprintf("%v-flatten.json", appName),
+		},
+		Cache: cacheDefs{
+			Filename: fmt.Sprintf("%v-cache.json", appName),
+		},
 	},
 	Environment: environmentDefs{
 		Home:   "PIXA_HOME",
@@ -106,6 +162,19 @@ var Definitions = definitions{
 	Filing: filingDefs{
 		JournalExt:    ".txt",
 		Discriminator: ".$",
+		Attempt:       "ATTEMPT",
+		ErrorExt:      ".error.txt",
+		DejaVu:        ".pixa-deja-vu",
+		Manifest:      ".pixa-manifest.json",
+		Xattr:         "user.pixa.processed",
+	},
+	Quality: qualityDefs{
+		Always: "always",
+		Sample: "sample",
+		Never:  "never",
+	},
+	ProfileKeys: profileKeyDefs{
+		TargetSize: "target-size",
 	},
 	Interaction: interactionDefs{
 		Names: struct {
@@ -2,6 +2,7 @@ package common
 
 import (
 	"io/fs"
+	"regexp"
 
 	"github.com/snivilised/extendio/xfs/nav"
 )
@@ -30,11 +31,13 @@ type (
 	PathFinder interface {
 		Transfer(info *PathInfo) (folder, file string)
 		Result(info *PathInfo) (folder, file string)
+		Quarantine(info *PathInfo) (folder, file string)
 		FolderSupplement(profile string) string
 		FileSupplement(profile, withSampling string) string
 		SampleFileSupplement(withSampling string) string
 		TransparentInput() bool
 		JournalFullPath(item *nav.TraverseItem) string
+		Register(item *nav.TraverseItem)
 		Statics() *StaticInfo
 		Scheme() string
 		Observe(o PathFinder) PathFinder
@@ -45,10 +48,91 @@ type (
 		FileExists(pathAt string) bool
 		DirectoryExists(pathAt string) bool
 		Create(path string, overwrite bool) error
+		EnsureFolder(path string) error
+		ReadFile(path string) ([]byte, error)
+		FileSize(path string) (int64, error)
+		Move(from, to string) error
+		Remove(path string) error
 		Setup(pi *PathInfo) (destination string, err error)
+		Reject(pi *PathInfo, destination string) error
+		Quarantine(pi *PathInfo, reason error) error
+		Reuse(from, to string, link bool) error
 		Tidy(pi *PathInfo) error
 	}
 
+	// ProcessedRecord is the record of a result produced by pixa, which
+	// allows an incremental run to recognise it.
+	ProcessedRecord struct {
+		Profile string `json:"profile"`
+		Version string `json:"version"`
+		Hash    string `json:"hash"`
+	}
+
+	// Ledger keeps the records of the results produced by pixa.
+	Ledger interface {
+		// Mark records that the file at path is a result of the profile.
+		Mark(path, profile string) error
+		// Processed determines whether the file at path is a result that
+		// has not changed since it was marked.
+		Processed(path string) bool
+	}
+
+	// CachedResult is the entry of the Cache for a result
+	CachedResult struct {
+		Result  string `json:"result"`
+		Hash    string `json:"hash"`
+		Command string `json:"command"`
+	}
+
+	// Cache is an index of the results produced by pixa across runs, keyed
+	// by the content hash of the input and then by profile, so that the
+	// result of an input that has already been processed elsewhere can be
+	// reused.
+	Cache interface {
+		// Lookup returns the earlier result of the input for the profile,
+		// produced by the same command, if it is still intact, along with
+		// the hash of the input.
+		Lookup(input, profile, command string) (result, hash string, err error)
+		// Record adds the result of the input with the hash.
+		Record(hash, profile, command, result string) error
+	}
+
+	// PathTemplate describes a layout of the paths created by the path
+	// finder, that may be overridden by the filing.templates config. A
+	// template is a / separated path of segments, that may contain fields,
+	// eg ${{SUPPLEMENT}}.
+	PathTemplate struct {
+		// Name is the key of the template in the config
+		Name string
+		// Root is the field that the template must start with, so that
+		// the path can't escape the location it denotes
+		Root string
+		// Fields are the fields available to the template, in addition
+		// to the GeneralTemplateFields
+		Fields []string
+		// Distinct indicates that the path must differ from the folder of
+		// the input, otherwise the input could be overwritten
+		Distinct bool
+	}
+
+	templateFieldDefs struct {
+		TransferDestination string
+		ItemSubPath         string
+		DejaVu              string
+		Supplement          string
+		OutputRoot          string
+		Date                string
+		Ext                 string
+		Profile             string
+		Scheme              string
+	}
+
+	pathTemplateDefs struct {
+		Transfer    PathTemplate
+		Result      PathTemplate
+		Transparent PathTemplate
+	}
+
 	permissions struct {
 		Write       fs.FileMode
 		Faydeaudeau fs.FileMode
@@ -67,3 +151,61 @@ var Permissions = permissions{
 	Faydeaudeau: faydeaudeau,
 	Beezledub:   beezledub,
 }
+
+// TemplateFields are the fields that may appear in a PathTemplate
+var TemplateFields = templateFieldDefs{
+	TransferDestination: "${{TRANSFER-DESTINATION}}",
+	ItemSubPath:         "${{ITEM-SUB-PATH}}",
+	DejaVu:              "${{DEJA-VU}}",
+	Supplement:          "${{SUPPLEMENT}}",
+	OutputRoot:          "${{OUTPUT-ROOT}}",
+	Date:                "${{DATE}}",
+	Ext:                 "${{EXT}}",
+	Profile:             "${{PROFILE}}",
+	Scheme:              "${{SCHEME}}",
+}
+
+// TemplateFieldPattern matches the fields of a PathTemplate
+var TemplateFieldPattern = regexp.MustCompile(`\$\{\{[A-Z-]+\}\}`)
+
+// GeneralTemplateFields are available to every PathTemplate
+var GeneralTemplateFields = []string{
+	TemplateFields.Date,
+	TemplateFields.Ext,
+	TemplateFields.Profile,
+	TemplateFields.Scheme,
+}
+
+// PathTemplates are the templates that may be overridden by config
+var PathTemplates = pathTemplateDefs{
+	// Transfer is the folder the input is moved to, out of the way of the
+	// result
+	Transfer: PathTemplate{
+		Name: "transfer",
+		Root: TemplateFields.TransferDestination,
+		Fields: []string{
+			TemplateFields.ItemSubPath,
+			TemplateFields.DejaVu,
+			TemplateFields.Supplement,
+		},
+		Distinct: true,
+	},
+	// Result is the folder of the result, when it does not take the place
+	// of the input
+	Result: PathTemplate{
+		Name: "result",
+		Root: TemplateFields.OutputRoot,
+		Fields: []string{
+			TemplateFields.ItemSubPath,
+			TemplateFields.Supplement,
+		},
+		Distinct: true,
+	},
+	// Transparent is the folder of the result, when it is alongside the
+	// input, ie the input has been moved out of the way, or the name of the
+	// result is decorated
+	Transparent: PathTemplate{
+		Name: "transparent",
+		Root: TemplateFields.OutputRoot,
+	},
+}
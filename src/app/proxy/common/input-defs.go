@@ -13,6 +13,9 @@ type (
 		NoFiles    uint
 		NoFolders  uint
 		Last       bool
+		// Simulate denotes a dry run (--dry-run=simulate) that runs against
+		// a copy of the directory tree, rather than just describing it
+		Simulate bool
 	}
 )
 
@@ -52,6 +55,66 @@ var SamplingFactorEnumInfo = assistant.NewEnumInfo(assistant.AcceptableEnumValue
 	SamplingFactor2x1En: []string{"2x1", "21", "2"},
 })
 
+// ErrorPolicyEnum denotes what happens to the run when an item fails
+type ErrorPolicyEnum int
+
+const (
+	_ ErrorPolicyEnum = iota
+	// ErrorPolicyAbortEn stops the traversal at the first failure
+	ErrorPolicyAbortEn
+	// ErrorPolicyContinueEn carries on with the next item
+	ErrorPolicyContinueEn
+	// ErrorPolicyQuarantineEn moves the input of the failed item into the
+	// failed folder, then carries on with the next item
+	ErrorPolicyQuarantineEn
+)
+
+var ErrorPolicyEnumInfo = assistant.NewEnumInfo(assistant.AcceptableEnumValues[ErrorPolicyEnum]{
+	ErrorPolicyAbortEn:      []string{"abort", "a"},
+	ErrorPolicyContinueEn:   []string{"continue", "c"},
+	ErrorPolicyQuarantineEn: []string{"quarantine", "q"},
+})
+
+// DupesActionEnum denotes what happens to the extra copies of an exact
+// duplicate
+type DupesActionEnum int
+
+const (
+	_ DupesActionEnum = iota
+	// DupesActionNoneEn only reports the duplicates
+	DupesActionNoneEn
+	// DupesActionTrashEn moves the extras into the trash folder alongside
+	DupesActionTrashEn
+	// DupesActionLinkEn replaces the extras with hard links to the keeper
+	DupesActionLinkEn
+)
+
+var DupesActionEnumInfo = assistant.NewEnumInfo(assistant.AcceptableEnumValues[DupesActionEnum]{
+	DupesActionNoneEn:  []string{"none", "n"},
+	DupesActionTrashEn: []string{"trash", "t"},
+	DupesActionLinkEn:  []string{"link", "l"},
+})
+
+// ConfigScopeEnum denotes the location of a config file
+type ConfigScopeEnum int
+
+const (
+	_ ConfigScopeEnum = iota
+	ConfigScopeDefaultEn
+	ConfigScopeHomeEn
+	ConfigScopeXDGEn
+	ConfigScopePlatformEn
+	ConfigScopeEnvEn
+)
+
+var ConfigScopeEnumInfo = assistant.NewEnumInfo(assistant.AcceptableEnumValues[ConfigScopeEnum]{
+	ConfigScopeDefaultEn:  []string{"default", "d"},
+	ConfigScopeHomeEn:     []string{"home", "h"},
+	ConfigScopeXDGEn:      []string{"xdg", "x"},
+	ConfigScopePlatformEn: []string{"platform", "p"},
+	ConfigScopeEnvEn:      []string{"env", "e"},
+})
+
 // ThirdPartySet represents flags that are only of use to the third party application
 // being invoked (ie magick). These flags are of no significance to pixa, but we have
 // to define them explicitly, because of a deficiency in cobra in the way it handles
@@ -88,6 +151,58 @@ type ShrinkParameterSet struct {
 	OutputPath string
 	TrashPath  string
 	Cuddle     bool
+	// Flatten sends every result directly to the output, without the
+	// sub-path of its input
+	Flatten bool
+	// Incremental skips the items that are results of an earlier run and
+	// have not changed since
+	Incremental bool
+	// Link hard links a cached result, rather than copying it
+	Link bool
+	// Force processes every item, even when incremental or cached
+	Force      bool
+	HTMLReport string
+	MinSSIM    float64
+	TargetSize string
+	// ErrorPolicyEn falls back to advanced.error-policy
+	ErrorPolicyEn assistant.EnumValue[ErrorPolicyEnum]
+	// MaxFailures falls back to advanced.max-failures
+	MaxFailures uint
+	// PlanFile is where the plan of a dry run is written, instead of stdout
+	PlanFile string
+	// Plan is where the plan for apply is written; implies a dry run
+	Plan string
+}
+
+type ReportParameterSet struct {
+	OutputPath string
+	ReportPath string
+}
+
+type RecommendParameterSet struct {
+	OutputPath string
+	Apply      bool
+}
+
+type DupesParameterSet struct {
+	// Threshold is the maximum number of differing bits between the
+	// perceptual hashes of near duplicates
+	Threshold uint
+	// Exact only finds duplicates with identical content
+	Exact bool
+	// ActionEn only applies to exact duplicates
+	ActionEn   assistant.EnumValue[DupesActionEnum]
+	ReportPath string
+}
+
+type ProfilesParameterSet struct {
+	Resolved bool
+}
+
+type ConfigParameterSet struct {
+	ScopeEn   assistant.EnumValue[ConfigScopeEnum]
+	Force     bool
+	Effective bool
 }
 
 type Observers struct {
@@ -113,3 +228,20 @@ type ShrinkCommandInputs struct {
 	ParamSet *assistant.ParamSet[ShrinkParameterSet]
 	PolyFam  *assistant.ParamSet[store.PolyFilterParameterSet]
 }
+
+type ReportCommandInputs struct {
+	Root     *RootCommandInputs
+	ParamSet *assistant.ParamSet[ReportParameterSet]
+}
+
+type RecommendCommandInputs struct {
+	Root     *RootCommandInputs
+	ParamSet *assistant.ParamSet[RecommendParameterSet]
+	Shrink   *ShrinkCommandInputs
+}
+
+type DupesCommandInputs struct {
+	Root     *RootCommandInputs
+	ParamSet *assistant.ParamSet[DupesParameterSet]
+	PolyFam  *assistant.ParamSet[store.PolyFilterParameterSet]
+}
@@ -36,9 +36,22 @@ type (
 		Destination string
 		Scheme      string
 		Profile     string
+		Quality     *QualityMetrics
+		Attempt     *TargetAttempt
 		Err         error
 	}
 
+	// TargetAttempt describes a single attempt at creating a result that
+	// fits within the target size. A progress message that carries an
+	// attempt is interim; it is followed by the message for the outcome.
+	//
+	TargetAttempt struct {
+		No      uint
+		Quality int
+		Size    int64
+		Target  int64
+	}
+
 	// FinishedMsg indicates end of traversal
 	//
 	FinishedMsg struct {
@@ -1,6 +1,12 @@
 package common
 
-import "github.com/snivilised/cobrass/src/clif"
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/snivilised/cobrass/src/clif"
+)
 
 type (
 	ExecutionAgent interface {
@@ -15,6 +21,10 @@ type (
 		// have those and merely has to formulate the complete command line in
 		// the correct order required by the third party program.
 		Invoke(thirdPartyCL clif.ThirdPartyCommandLine, source, destination string) error
+
+		// CommandLine returns the complete command line that Invoke runs,
+		// starting with the name of the program.
+		CommandLine(thirdPartyCL clif.ThirdPartyCommandLine, source, destination string) []string
 	}
 
 	Executor interface {
@@ -22,4 +32,64 @@ type (
 		Look() (string, error)
 		Execute(args ...string) error
 	}
+
+	// ExecutionError describes a failed invocation of the third party
+	// program. The output of the program is captured so that the reason
+	// for the failure can be reported, but only up to a limit, so Stdout
+	// and Stderr may be truncated. ExitCode is -1 if the program could not
+	// be run, or did not exit normally.
+	ExecutionError struct {
+		Program   string
+		Args      []string
+		ExitCode  int
+		Stdout    string
+		Stderr    string
+		Truncated bool
+		Err       error
+	}
 )
+
+// Error returns the stderr of the program on a single line, since that is
+// the most useful account of why it failed.
+func (e *ExecutionError) Error() string {
+	if reason := e.Reason(); reason != "" {
+		return fmt.Sprintf("'%v' exited with code %v: %v", e.Program, e.ExitCode, reason)
+	}
+
+	return fmt.Sprintf("'%v' failed: %v", e.Program, e.Err)
+}
+
+func (e *ExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// Reason returns the non blank lines of stderr joined together.
+func (e *ExecutionError) Reason() string {
+	return strings.Join(e.StderrLines(), "; ")
+}
+
+// StderrLines returns the non blank lines of stderr.
+func (e *ExecutionError) StderrLines() []string {
+	lines := []string{}
+
+	for _, line := range strings.Split(e.Stderr, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// LogValue allows the error to be logged with structured fields.
+func (e *ExecutionError) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("program", e.Program),
+		slog.String("args", strings.Join(e.Args, " ")),
+		slog.Int("exit-code", e.ExitCode),
+		slog.String("stderr", e.Stderr),
+		slog.String("stdout", e.Stdout),
+		slog.Bool("truncated", e.Truncated),
+		slog.String("error", fmt.Sprintf("%v", e.Err)),
+	)
+}
@@ -1,6 +1,10 @@
 package common
 
-import "github.com/snivilised/extendio/xfs/nav"
+import (
+	"sync/atomic"
+
+	"github.com/snivilised/extendio/xfs/nav"
+)
 
 type (
 	SessionControllerInfo struct {
@@ -8,6 +12,17 @@ type (
 		Inputs      *ShrinkCommandInputs
 		FileManager FileManager
 		Interaction UserInteraction
+		Recorder    Recorder
+		// Planner is only present in a dry run.
+		Planner Planner
+		// Ledger records the results, so that they are recognised by an
+		// incremental run
+		Ledger Ledger
+		// Cache is only present when the results are to be cached
+		Cache Cache
+		// Failures counts the items that have failed across all
+		// controllers, so that max-failures can be enforced.
+		Failures *atomic.Uint32
 	}
 
 	PrivateControllerInfo struct {
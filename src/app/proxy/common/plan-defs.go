@@ -0,0 +1,26 @@
+package common
+
+type (
+	// PlannedOperation describes what a step would do, without doing it;
+	// the move of the input out of the way during setup (Input differs
+	// from Source), the command line of the third party program and the
+	// path of the result it would create. When the step searches for a
+	// quality that fits within a target size (in bytes), the command is
+	// only the starting point of that search.
+	PlannedOperation struct {
+		Source     string
+		Input      string
+		Scheme     string
+		Profile    string
+		Command    []string
+		Result     string
+		TargetSize int64
+	}
+
+	// Planner accumulates the operations of a dry run. Since steps may be
+	// executed concurrently by the worker pool, implementations must be
+	// safe for concurrent use.
+	Planner interface {
+		Plan(op *PlannedOperation)
+	}
+)
@@ -0,0 +1,20 @@
+package common
+
+import (
+	"maps"
+
+	"github.com/snivilised/cobrass/src/clif"
+)
+
+// WithoutProfileKeys returns the flags of a profile that are passed onto
+// the third party program, ie without the keys interpreted by pixa.
+func WithoutProfileKeys(flags clif.ChangedFlagsMap) clif.ChangedFlagsMap {
+	if _, found := flags[Definitions.ProfileKeys.TargetSize]; !found {
+		return flags
+	}
+
+	result := maps.Clone(flags)
+	delete(result, Definitions.ProfileKeys.TargetSize)
+
+	return result
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"fmt"
+	"math"
+)
+
+type (
+	// Recorder accumulates the outcome of each executed step, so that
+	// a summary of the whole run can be produced once the traversal
+	// has completed. Since steps may be executed concurrently by the
+	// worker pool, implementations must be safe for concurrent use.
+	Recorder interface {
+		Record(msg *ProgressMsg)
+	}
+)
+
+type (
+	// QualityMetrics represents how closely a result resembles the source
+	// image it was created from.
+	QualityMetrics struct {
+		// SSIM is the mean structural similarity index, in the range [-1, 1],
+		// where 1 indicates the images are identical.
+		SSIM float64
+
+		// PSNR is the peak signal to noise ratio in decibels; identical images
+		// have an infinite ratio.
+		PSNR float64
+	}
+)
+
+func (m *QualityMetrics) String() string {
+	if math.IsInf(m.PSNR, 1) {
+		return fmt.Sprintf("ssim: %.4f, psnr: ∞", m.SSIM)
+	}
+
+	return fmt.Sprintf("ssim: %.4f, psnr: %.2f dB", m.SSIM, m.PSNR)
+}
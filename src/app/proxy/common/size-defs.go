@@ -0,0 +1,52 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type sizeUnit struct {
+	suffix     string
+	multiplier float64
+}
+
+// sizeUnits is ordered so that the longer suffixes are matched first; ie
+// KiB must be tried before B.
+var sizeUnits = []sizeUnit{
+	{suffix: "kib", multiplier: 1 << 10},
+	{suffix: "mib", multiplier: 1 << 20},
+	{suffix: "gib", multiplier: 1 << 30},
+	{suffix: "kb", multiplier: 1e3},
+	{suffix: "mb", multiplier: 1e6},
+	{suffix: "gb", multiplier: 1e9},
+	{suffix: "k", multiplier: 1e3},
+	{suffix: "m", multiplier: 1e6},
+	{suffix: "g", multiplier: 1e9},
+	{suffix: "b", multiplier: 1},
+}
+
+// ParseByteSize parses a human readable size such as "500KB", "1.5MiB"
+// or "20000" into a number of bytes. Decimal units (KB, MB, GB) are
+// powers of 1000 and binary units (KiB, MiB, GiB) are powers of 1024;
+// a value without a unit is in bytes.
+func ParseByteSize(size string) (int64, error) {
+	value := strings.ToLower(strings.TrimSpace(size))
+	multiplier := float64(1)
+
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+
+			break
+		}
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("invalid size: '%v'", size)
+	}
+
+	return int64(number * multiplier), nil
+}
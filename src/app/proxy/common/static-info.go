@@ -11,6 +11,8 @@ type StaticInfo struct {
 	Journal    JournalMetaInfo
 	Legacy     string
 	Trash      string
+	Failed     string
+	DejaVu     string
 	Fake       string
 	Supplement string
 	Sample     string
@@ -21,6 +23,8 @@ func NewStaticInfoFromConfig(advanced AdvancedConfig) *StaticInfo {
 		Adhoc:      advanced.AdhocLabel(),
 		Legacy:     advanced.LegacyLabel(),
 		Trash:      advanced.TrashLabel(),
+		Failed:     advanced.FailedLabel(),
+		DejaVu:     advanced.DejaVuLabel(),
 		Fake:       advanced.FakeLabel(),
 		Supplement: advanced.SupplementLabel(),
 		Sample:     advanced.SampleLabel(),
@@ -78,6 +82,38 @@ func (i *StaticInfo) FileSupplement(baseFilename, supp string) string {
 	return fmt.Sprintf("%v.%v", baseFilename, supp)
 }
 
+// AttemptLocation returns the path of the temporary file in which an
+// attempt at the destination is created, before it is accepted.
+func (i *StaticInfo) AttemptLocation(destination string) string {
+	ext := filepath.Ext(destination)
+	withoutExt := strings.TrimSuffix(destination, ext)
+
+	return i.FileSupplement(withoutExt, fmt.Sprintf("$%v$", Definitions.Filing.Attempt)) + ext
+}
+
 func (i *StaticInfo) TrashTag() string {
 	return fmt.Sprintf("$%v$", i.Trash)
 }
+
+// DejaVuTag is the name of the folder into which inputs are transferred
+// out of the way of their results (see DEJA-VU in path-finder). Without
+// a label of its own, it is the trash folder, as it always has been.
+func (i *StaticInfo) DejaVuTag() string {
+	if i.DejaVu == "" {
+		return i.TrashTag()
+	}
+
+	return fmt.Sprintf("$%v$", i.DejaVu)
+}
+
+// FailedTag is the name of the folder into which the inputs of failed
+// items are quarantined.
+func (i *StaticInfo) FailedTag() string {
+	return fmt.Sprintf("$%v$", i.Failed)
+}
+
+// ErrorSidecar returns the path of the file that describes why the
+// quarantined file at path failed.
+func (i *StaticInfo) ErrorSidecar(path string) string {
+	return path + Definitions.Filing.ErrorExt
+}
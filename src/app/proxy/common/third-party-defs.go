@@ -0,0 +1,17 @@
+package common
+
+import (
+	"github.com/snivilised/cobrass"
+)
+
+// ThirdPartyFlags are the flags of the third party program that are known
+// to pixa, mapped to their short forms.
+var ThirdPartyFlags = cobrass.KnownByCollection{
+	// third-party: (perhaps third party parameters should not have short codes)
+	//
+	"gaussian-blur":   "b",
+	"sampling-factor": "f",
+	"interlace":       "i",
+	"strip":           "s",
+	"quality":         "q",
+}
@@ -0,0 +1,205 @@
+package dupes_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/dupes"
+)
+
+// scene draws a picture with enough structure to be hashed; the variant
+// changes the structure, producing a different picture.
+func scene(width, height, variant int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := range height {
+		for x := range width {
+			cx, cy := x*8/width, y*8/height
+			v := uint8((x * 255 / width) ^ (((cx + cy*variant) % 3) * 60))
+			img.Set(x, y, color.RGBA{R: v, G: uint8(y * 255 / height), B: 255 - v, A: 255})
+		}
+	}
+
+	return img
+}
+
+var _ = Describe("Dupes", func() {
+	var (
+		vfs       storage.VirtualFS
+		directory string
+	)
+
+	write := func(name string, img image.Image, quality int) string {
+		var buffer bytes.Buffer
+
+		if quality == 0 {
+			Expect(png.Encode(&buffer, img)).To(Succeed())
+		} else {
+			Expect(jpeg.Encode(&buffer, img, &jpeg.Options{Quality: quality})).To(Succeed())
+		}
+
+		path := filepath.Join(directory, name)
+		Expect(vfs.WriteFile(path, buffer.Bytes(), common.Permissions.Beezledub)).To(Succeed())
+
+		return path
+	}
+
+	take := func(paths ...string) []*dupes.Fingerprint {
+		prints := []*dupes.Fingerprint{}
+
+		for _, path := range paths {
+			fp, err := dupes.Take(vfs, path)
+			Expect(err).To(Succeed())
+			prints = append(prints, fp)
+		}
+
+		return prints
+	}
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		directory = GinkgoT().TempDir()
+	})
+
+	Context("Take", func() {
+		It("🧪 should: hash re-encoded and resized copies closely", func() {
+			prints := take(
+				write("original.png", scene(320, 240, 1), 0),
+				write("compressed.jpg", scene(320, 240, 1), 40),
+				write("resized.png", scene(160, 120, 1), 0),
+				write("other.png", scene(320, 240, 2), 0),
+			)
+
+			for _, copied := range prints[1:3] {
+				Expect(dupes.Distance(prints[0].DHash, copied.DHash)).To(BeNumerically("<=", 8))
+				Expect(dupes.Distance(prints[0].PHash, copied.PHash)).To(BeNumerically("<=", 8))
+			}
+
+			Expect(dupes.Distance(prints[0].PHash, prints[3].PHash)).To(BeNumerically(">", 8))
+		})
+
+		When("file is not an image", func() {
+			It("🧪 should: only hash content", func() {
+				path := filepath.Join(directory, "notes.jpg")
+				Expect(vfs.WriteFile(path, []byte("not an image"), common.Permissions.Beezledub)).To(Succeed())
+
+				fp, err := dupes.Take(vfs, path)
+				Expect(err).To(Succeed())
+				Expect(fp.Perceptual).To(BeFalse())
+				Expect(fp.Exact).NotTo(BeEmpty())
+			})
+		})
+	})
+
+	Context("Find", func() {
+		var (
+			prints []*dupes.Fingerprint
+		)
+
+		BeforeEach(func() {
+			original := write("a.png", scene(320, 240, 1), 0)
+			copied := filepath.Join(directory, "b.png")
+			content, _ := vfs.ReadFile(original)
+			Expect(vfs.WriteFile(copied, content, common.Permissions.Beezledub)).To(Succeed())
+
+			prints = take(original, copied,
+				write("c.jpg", scene(160, 120, 1), 60),
+				write("d.png", scene(320, 240, 2), 0),
+			)
+		})
+
+		It("🧪 should: group exact and near duplicates", func() {
+			report := dupes.Find(prints, 10, false)
+
+			Expect(report.Scanned).To(Equal(4))
+			Expect(report.Groups).To(HaveLen(2))
+
+			exact := report.Groups[0]
+			Expect(exact.Kind).To(Equal(dupes.KindExact))
+			Expect(exact.Keeper.Path).To(HaveSuffix("a.png"))
+			Expect(exact.Extras).To(HaveLen(1))
+			Expect(exact.Extras[0].Path).To(HaveSuffix("b.png"))
+			Expect(report.Reclaimable).To(Equal(exact.Extras[0].Size))
+
+			near := report.Groups[1]
+			Expect(near.Kind).To(Equal(dupes.KindNear))
+			Expect(near.Keeper.Path).To(HaveSuffix("a.png"), "the larger image is kept")
+			Expect(near.Extras).To(HaveLen(1))
+			Expect(near.Extras[0].Path).To(HaveSuffix("c.jpg"))
+		})
+
+		When("exact requested", func() {
+			It("🧪 should: only group exact duplicates", func() {
+				report := dupes.Find(prints, 10, true)
+
+				Expect(report.Groups).To(HaveLen(1))
+				Expect(report.Groups[0].Kind).To(Equal(dupes.KindExact))
+			})
+		})
+
+		When("hashes differ in every segment", func() {
+			It("🧪 should: only group those within threshold", func() {
+				const (
+					threshold = 10
+					base      = uint64(0x0123456789abcdef)
+				)
+
+				// flips the bit at offset in each of the first count segments
+				// of the 64 bit hash, when split into threshold+1 segments,
+				// which is the worst case for finding a segment in common.
+				//
+				flip := func(count, offset int) uint64 {
+					hash := base
+
+					for segment := range count {
+						hash ^= 1 << (segment*64/(threshold+1) + offset)
+					}
+
+					return hash
+				}
+				synthetic := func(path string, hash uint64) *dupes.Fingerprint {
+					return &dupes.Fingerprint{
+						Path:       path,
+						Exact:      path,
+						DHash:      hash,
+						PHash:      hash,
+						Perceptual: true,
+					}
+				}
+
+				report := dupes.Find([]*dupes.Fingerprint{
+					synthetic("a.png", base),
+					synthetic("b.png", flip(threshold, 0)),
+					synthetic("c.png", flip(threshold+1, 1)),
+				}, threshold, false)
+
+				Expect(report.Groups).To(HaveLen(1))
+				Expect(report.Groups[0].Keeper.Path).To(Equal("a.png"))
+				Expect(report.Groups[0].Extras).To(HaveLen(1))
+				Expect(report.Groups[0].Extras[0].Path).To(Equal("b.png"))
+				Expect(report.Groups[0].Distance).To(Equal(threshold))
+			})
+		})
+
+		It("🧪 should: render report", func() {
+			var buffer bytes.Buffer
+
+			Expect(dupes.Render(&buffer, dupes.Find(prints, 10, false))).To(Succeed())
+			Expect(buffer.String()).To(ContainSubstring("👯 exact (1 extras)"))
+			Expect(buffer.String()).To(ContainSubstring("🫧 near (1 extras"))
+			Expect(buffer.String()).To(HaveSuffix("🧮 scanned: 4, exact: 1, near: 1, reclaimable: " +
+				fmt.Sprint(prints[1].Size) + " bytes\n",
+			))
+		})
+	})
+})
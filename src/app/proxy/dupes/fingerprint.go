@@ -0,0 +1,205 @@
+package dupes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"math"
+	"math/bits"
+	"slices"
+
+	// register the decoders for the formats that can be hashed perceptually
+	//
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/snivilised/extendio/xfs/storage"
+)
+
+const (
+	// dSize is the width of the grid from which the difference hash is
+	// derived; the height is one less, giving 64 adjacent pairs
+	dSize = 9
+	// pSize is the size of the grid from which the perceptual hash is
+	// derived
+	pSize = 32
+	// pLow is the size of the block of low frequencies of the perceptual
+	// hash, which gives 64 coefficients
+	pLow = 8
+	// samples is the maximum number of pixels sampled along each axis of
+	// a cell when the image is reduced to a grid, which bounds the cost of
+	// hashing a very large image
+	samples = 16
+)
+
+// Fingerprint identifies an image both exactly, by the hash of its content,
+// and perceptually, by hashes of its appearance that are resistant to
+// re-encoding, resizing and minor edits.
+type Fingerprint struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	Width  int    `json:"width,omitempty"`
+	Height int    `json:"height,omitempty"`
+	// Exact is the hash of the content
+	Exact string `json:"-"`
+	// DHash is the difference hash; the gradient of the luma between
+	// horizontally adjacent cells
+	DHash uint64 `json:"-"`
+	// PHash is the perceptual hash; the sign of the low frequencies of the
+	// discrete cosine transform of the luma relative to their median
+	PHash uint64 `json:"-"`
+	// Perceptual indicates that the image could be decoded, so that the
+	// perceptual hashes are available
+	Perceptual bool `json:"-"`
+}
+
+// Take creates the fingerprint of the file at path. A file that can't be
+// decoded as an image only has an exact hash, so it can still be found to
+// be a duplicate, but not a near duplicate.
+func Take(vfs storage.VirtualFS, path string) (*Fingerprint, error) {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(content)
+	fp := &Fingerprint{
+		Path:  path,
+		Size:  int64(len(content)),
+		Exact: hex.EncodeToString(sum[:]),
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil || img.Bounds().Empty() {
+		return fp, nil //nolint:nilerr // not an image that can be decoded
+	}
+
+	fp.Width, fp.Height = img.Bounds().Dx(), img.Bounds().Dy()
+	fp.DHash = dHash(grid(img, dSize, dSize-1), dSize)
+	fp.PHash = pHash(grid(img, pSize, pSize))
+	fp.Perceptual = true
+
+	return fp, nil
+}
+
+// Distance returns the number of bits that differ between the hashes
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// grid reduces the image to the luma of a grid of cells, indexed by
+// y*width+x, where each cell is the mean of a sample of the pixels it
+// covers.
+func grid(img image.Image, width, height int) []float64 {
+	const (
+		shift = 8
+		// ITU-R BT.601 luma coefficients
+		kr = 0.299
+		kg = 0.587
+		kb = 0.114
+	)
+
+	bounds := img.Bounds()
+	cells := make([]float64, width*height)
+
+	for y := range height {
+		top, bottom := span(bounds.Min.Y, bounds.Dy(), y, height)
+
+		for x := range width {
+			left, right := span(bounds.Min.X, bounds.Dx(), x, width)
+			stepY, stepX := max((bottom-top)/samples, 1), max((right-left)/samples, 1)
+
+			var sum, count float64
+
+			for sy := top; sy < bottom; sy += stepY {
+				for sx := left; sx < right; sx += stepX {
+					r, g, b, _ := img.At(sx, sy).RGBA()
+					sum += kr*float64(r>>shift) + kg*float64(g>>shift) + kb*float64(b>>shift)
+					count++
+				}
+			}
+
+			cells[y*width+x] = sum / count
+		}
+	}
+
+	return cells
+}
+
+// span returns the range of pixels covered by the cell at index, when
+// extent pixels are divided into noOf cells. Every cell covers at least
+// one pixel, even when the image is smaller than the grid.
+func span(start, extent, index, noOf int) (from, to int) {
+	from = start + index*extent/noOf
+	to = start + (index+1)*extent/noOf
+
+	if to <= from {
+		to = from + 1
+	}
+
+	return from, to
+}
+
+func dHash(cells []float64, width int) uint64 {
+	var hash uint64
+
+	for y := range width - 1 {
+		for x := range width - 1 {
+			hash <<= 1
+
+			if cells[y*width+x] < cells[y*width+x+1] {
+				hash |= 1
+			}
+		}
+	}
+
+	return hash
+}
+
+func pHash(cells []float64) uint64 {
+	coefficients := make([]float64, 0, pLow*pLow)
+
+	for v := range pLow {
+		for u := range pLow {
+			coefficients = append(coefficients, dct(cells, u, v))
+		}
+	}
+
+	// the first coefficient is the mean, which says nothing about the
+	// structure of the image, so is left out of the median
+	//
+	sorted := slices.Clone(coefficients[1:])
+	slices.Sort(sorted)
+	median := (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+
+	var hash uint64
+
+	for _, coefficient := range coefficients {
+		hash <<= 1
+
+		if coefficient > median {
+			hash |= 1
+		}
+	}
+
+	return hash
+}
+
+// dct returns the coefficient of the two dimensional discrete cosine
+// transform (type II) of the cells at frequency (u, v)
+func dct(cells []float64, u, v int) float64 {
+	var sum float64
+
+	for y := range pSize {
+		cy := math.Cos(float64(2*y+1) * float64(v) * math.Pi / (2 * pSize))
+
+		for x := range pSize {
+			sum += cells[y*pSize+x] * cy *
+				math.Cos(float64(2*x+1)*float64(u)*math.Pi/(2*pSize))
+		}
+	}
+
+	return sum
+}
@@ -0,0 +1,245 @@
+package dupes
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Kind denotes how the images of a group are duplicates of each other
+type Kind string
+
+const (
+	// KindExact denotes images with identical content
+	KindExact Kind = "exact"
+	// KindNear denotes images that look the same, but whose content
+	// differs, eg the same photo encoded at a different quality or size
+	KindNear Kind = "near"
+)
+
+// Group is a set of duplicates, of which one is kept and the others are
+// considered to be extras.
+type Group struct {
+	Kind   Kind           `json:"kind"`
+	Keeper *Fingerprint   `json:"keeper"`
+	Extras []*Fingerprint `json:"extras"`
+	// Distance is the greatest perceptual distance between an extra and
+	// the keeper; always 0 for exact duplicates
+	Distance int `json:"distance"`
+}
+
+// Report is the outcome of a search for duplicates
+type Report struct {
+	Scanned int      `json:"scanned"`
+	Groups  []*Group `json:"groups"`
+	// Reclaimable is the number of bytes occupied by the extras of the
+	// exact groups
+	Reclaimable int64 `json:"reclaimable"`
+}
+
+// Find groups the fingerprints into duplicates. Images with identical
+// content form exact groups. Then, unless exact is requested, images
+// whose difference and perceptual hashes are both within threshold bits
+// of each other form near groups; only the keeper of an exact group takes
+// part, as its extras are already accounted for. Near duplicates are
+// grouped transitively, so the members of a near group are not
+// necessarily all within the threshold of the keeper; the distance of the
+// group shows how far apart they are.
+func Find(prints []*Fingerprint, threshold int, exact bool) *Report {
+	report := &Report{
+		Scanned: len(prints),
+		Groups:  []*Group{},
+	}
+	ordered := slices.Clone(prints)
+	slices.SortFunc(ordered, func(a, b *Fingerprint) int {
+		return cmp.Compare(a.Path, b.Path)
+	})
+
+	buckets := map[string][]*Fingerprint{}
+	keys := []string{}
+
+	for _, fp := range ordered {
+		if _, found := buckets[fp.Exact]; !found {
+			keys = append(keys, fp.Exact)
+		}
+
+		buckets[fp.Exact] = append(buckets[fp.Exact], fp)
+	}
+
+	representatives := make([]*Fingerprint, 0, len(keys))
+
+	for _, key := range keys {
+		group := form(KindExact, buckets[key])
+		representatives = append(representatives, group.Keeper)
+
+		if len(group.Extras) > 0 {
+			report.Groups = append(report.Groups, group)
+
+			for _, extra := range group.Extras {
+				report.Reclaimable += extra.Size
+			}
+		}
+	}
+
+	if exact {
+		return report
+	}
+
+	candidates := slices.DeleteFunc(representatives, func(fp *Fingerprint) bool {
+		return !fp.Perceptual
+	})
+	sets := newDisjointSets(len(candidates))
+
+	for _, bucket := range segmentBuckets(candidates, threshold) {
+		for x, i := range bucket {
+			for _, j := range bucket[x+1:] {
+				if sets.find(i) != sets.find(j) && near(candidates[i], candidates[j], threshold) {
+					sets.union(i, j)
+				}
+			}
+		}
+	}
+
+	members := map[int][]*Fingerprint{}
+	roots := []int{}
+
+	for i, fp := range candidates {
+		root := sets.find(i)
+
+		if _, found := members[root]; !found {
+			roots = append(roots, root)
+		}
+
+		members[root] = append(members[root], fp)
+	}
+
+	for _, root := range roots {
+		if len(members[root]) > 1 {
+			report.Groups = append(report.Groups, form(KindNear, members[root]))
+		}
+	}
+
+	return report
+}
+
+// segmentBuckets returns the indices of the candidates that could be near
+// each other, so that not every pair has to be compared. The difference
+// hash is split into threshold+1 segments; by the pigeonhole principle,
+// the hashes of a pair within threshold bits of each other are identical
+// in at least one of them, so only candidates that share a segment are
+// bucketed together. When there are more segments than bits, every pair
+// has to be compared, so all the candidates are in the same bucket.
+func segmentBuckets(candidates []*Fingerprint, threshold int) [][]int {
+	const (
+		hashBits = 64
+	)
+
+	segments := threshold + 1
+
+	if segments > hashBits {
+		all := make([]int, len(candidates))
+
+		for i := range all {
+			all[i] = i
+		}
+
+		return [][]int{all}
+	}
+
+	type segmentKey struct {
+		segment int
+		value   uint64
+	}
+
+	buckets := map[segmentKey][]int{}
+	keys := []segmentKey{}
+
+	for i, fp := range candidates {
+		for segment := range segments {
+			// the widths of the segments differ by at most 1 bit
+			//
+			from := segment * hashBits / segments
+			to := (segment + 1) * hashBits / segments
+			key := segmentKey{
+				segment: segment,
+				value:   (fp.DHash >> from) & (1<<(to-from) - 1),
+			}
+
+			if _, found := buckets[key]; !found {
+				keys = append(keys, key)
+			}
+
+			buckets[key] = append(buckets[key], i)
+		}
+	}
+
+	result := make([][]int, 0, len(keys))
+
+	for _, key := range keys {
+		if len(buckets[key]) > 1 {
+			result = append(result, buckets[key])
+		}
+	}
+
+	return result
+}
+
+func near(a, b *Fingerprint, threshold int) bool {
+	return Distance(a.DHash, b.DHash) <= threshold && Distance(a.PHash, b.PHash) <= threshold
+}
+
+// form creates the group from its members, keeping the one with the most
+// pixels, then the largest file, then the first path, so that the best
+// copy is the one kept.
+func form(kind Kind, members []*Fingerprint) *Group {
+	ordered := slices.Clone(members)
+	slices.SortStableFunc(ordered, func(a, b *Fingerprint) int {
+		return cmp.Or(
+			cmp.Compare(b.Width*b.Height, a.Width*a.Height),
+			cmp.Compare(b.Size, a.Size),
+			cmp.Compare(a.Path, b.Path),
+		)
+	})
+
+	group := &Group{
+		Kind:   kind,
+		Keeper: ordered[0],
+		Extras: ordered[1:],
+	}
+
+	if kind == KindNear {
+		for _, extra := range group.Extras {
+			group.Distance = max(group.Distance, Distance(group.Keeper.PHash, extra.PHash))
+		}
+	}
+
+	return group
+}
+
+// disjointSets is a union-find over indices, used to group near
+// duplicates transitively.
+type disjointSets []int
+
+func newDisjointSets(size int) disjointSets {
+	sets := make(disjointSets, size)
+
+	for i := range sets {
+		sets[i] = i
+	}
+
+	return sets
+}
+
+func (s disjointSets) find(i int) int {
+	for s[i] != i {
+		s[i] = s[s[i]]
+		i = s[i]
+	}
+
+	return i
+}
+
+func (s disjointSets) union(i, j int) {
+	if a, b := s.find(i), s.find(j); a != b {
+		s[max(a, b)] = min(a, b)
+	}
+}
@@ -0,0 +1,52 @@
+package dupes
+
+import (
+	"fmt"
+	"io"
+)
+
+// Render writes the report as text; each group lists its keeper followed by
+// its extras, then a tally.
+func Render(w io.Writer, report *Report) error {
+	exact, near := 0, 0
+
+	for _, group := range report.Groups {
+		content := ""
+
+		switch group.Kind {
+		case KindExact:
+			exact++
+			content = fmt.Sprintf("👯 exact (%v extras)\n", len(group.Extras))
+
+		case KindNear:
+			near++
+			content = fmt.Sprintf("🫧 near (%v extras, distance: %v)\n",
+				len(group.Extras), group.Distance,
+			)
+		}
+
+		content += line("✅", group.Keeper)
+
+		for _, extra := range group.Extras {
+			content += line("➖", extra)
+		}
+
+		if _, err := io.WriteString(w, content); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "🧮 scanned: %v, exact: %v, near: %v, reclaimable: %v bytes\n",
+		report.Scanned, exact, near, report.Reclaimable,
+	)
+
+	return err
+}
+
+func line(marker string, fp *Fingerprint) string {
+	if fp.Width == 0 {
+		return fmt.Sprintf("  %v %v (%v bytes)\n", marker, fp.Path, fp.Size)
+	}
+
+	return fmt.Sprintf("  %v %v (%vx%v, %v bytes)\n", marker, fp.Path, fp.Width, fp.Height, fp.Size)
+}
@@ -0,0 +1,221 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/lorax/boost"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+	"github.com/snivilised/pixa/src/app/proxy/ipc"
+	"github.com/snivilised/pixa/src/app/proxy/plan"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+	"github.com/snivilised/pixa/src/app/proxy/user"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var (
+	applierRoutineName = boost.GoRoutineName("🧩 pixa-applier")
+)
+
+type ApplyParams struct {
+	// Path is the path of the plan file
+	Path string
+	// Program is the configured executable; a plan that runs any other
+	// program is rejected.
+	Program string
+	// Workers is the number of items applied concurrently
+	Workers int
+	Logger  *slog.Logger
+	Vfs     storage.VirtualFS
+}
+
+// ApplyEntry executes the operations of a plan created by shrink --plan.
+// Unlike the other entries, there is no traversal, because the plan
+// already identifies every input.
+type ApplyEntry struct {
+	Params      *ApplyParams
+	Interaction common.UserInteraction
+	Collector   *report.Collector
+}
+
+func EnterApply(params *ApplyParams) error {
+	doc, err := plan.Read(params.Vfs, params.Path)
+	if err != nil {
+		return locale.NewInvalidPlanError(params.Path, err)
+	}
+
+	if err := doc.Validate(params.Program); err != nil {
+		return locale.NewInvalidPlanError(params.Path, err)
+	}
+
+	executor := &ipc.ProgramExecutor{
+		Name: params.Program,
+	}
+
+	if _, err := executor.Look(); err != nil {
+		return locale.NewMissingExecutableError(params.Program)
+	}
+
+	params.Logger.Info("applying plan",
+		slog.String("path", params.Path),
+		slog.String("directory", doc.Directory),
+		slog.Int("items", len(doc.Items)),
+	)
+
+	entry := &ApplyEntry{
+		Params:      params,
+		Interaction: user.NewLinearInteraction(params.Logger),
+		Collector:   report.NewCollector(),
+	}
+	entry.run(doc.Items)
+
+	return summarise(params.Logger, entry.Collector)
+}
+
+// run applies the items with the worker pool, unless sequential
+// processing was requested.
+func (e *ApplyEntry) run(items []*plan.Item) {
+	if e.Params.Workers <= 1 {
+		for _, item := range items {
+			e.apply(item)
+		}
+
+		return
+	}
+
+	wgan := boost.NewAnnotatedWaitGroup("🍂 apply", e.Params.Logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	jobsCh := make(boost.JobStream[*plan.Item], DefaultJobsChSize)
+	pool := boost.NewWorkerPool[*plan.Item, struct{}](
+		&boost.NewWorkerPoolParams[*plan.Item, struct{}]{
+			NoWorkers: e.Params.Workers,
+			Exec: func(job boost.Job[*plan.Item]) (boost.JobOutput[struct{}], error) {
+				e.apply(job.Input)
+
+				return boost.JobOutput[struct{}]{}, nil
+			},
+			JobsCh:   jobsCh,
+			CancelCh: make(boost.CancelStream, 1),
+			WaitAQ:   wgan,
+			Logger:   e.Params.Logger,
+		},
+	)
+
+	wgan.Add(1, pool.RoutineName)
+
+	go pool.Start(ctx, cancel, nil)
+
+	for no, item := range items {
+		jobsCh <- boost.Job[*plan.Item]{
+			ID:         item.Source,
+			Input:      item,
+			SequenceNo: no,
+		}
+	}
+
+	close(jobsCh)
+	wgan.Wait(applierRoutineName)
+}
+
+// apply executes the steps of the item, as long as its input has not
+// changed since it was planned. When every step fails, the input is
+// moved back out of the trash, so that the item can be applied again.
+func (e *ApplyEntry) apply(item *plan.Item) {
+	fingerprint, err := plan.TakeFingerprint(e.Params.Vfs, item.Source)
+	if err == nil && !fingerprint.Equal(item.Fingerprint) {
+		err = locale.NewInputChangedError(item.Source)
+	}
+
+	if err == nil && item.Trash != "" {
+		err = e.transfer(item)
+	}
+
+	if err != nil {
+		e.record(item, &plan.Step{}, err)
+
+		return
+	}
+
+	failures := 0
+
+	for _, step := range item.Steps {
+		err = filing.MakeFolder(e.Params.Vfs, filepath.Dir(step.Result))
+
+		if err == nil {
+			executor := &ipc.ProgramExecutor{
+				Name: step.Command[0],
+			}
+			err = executor.Execute(step.Command[1:]...)
+		}
+
+		if err != nil {
+			failures++
+		}
+
+		e.record(item, step, err)
+	}
+
+	if item.Trash != "" && failures == len(item.Steps) {
+		e.restore(item)
+	}
+}
+
+// transfer moves the input out of the way of the results, as setup would
+// have done.
+func (e *ApplyEntry) transfer(item *plan.Item) error {
+	if e.Params.Vfs.FileExists(item.Trash) {
+		return fmt.Errorf("destination file: '%v' already exists", item.Trash)
+	}
+
+	if err := filing.MakeFolder(e.Params.Vfs, filepath.Dir(item.Trash)); err != nil {
+		return err
+	}
+
+	return e.Params.Vfs.Rename(item.Source, item.Trash)
+}
+
+// restore reverses the transfer of the input. A result left behind by
+// a failed step may be occupying the input's location, in which case the
+// input remains in the trash.
+func (e *ApplyEntry) restore(item *plan.Item) {
+	var err error
+
+	if e.Params.Vfs.FileExists(item.Source) {
+		err = fmt.Errorf("destination file: '%v' already exists", item.Source)
+	} else {
+		err = e.Params.Vfs.Rename(item.Trash, item.Source)
+	}
+
+	if err != nil {
+		e.Params.Logger.Error("could not restore input",
+			slog.String("source", item.Source),
+			slog.String("trash", item.Trash),
+			slog.String("error", err.Error()),
+		)
+
+		return
+	}
+
+	e.Params.Logger.Info("restored input",
+		slog.String("source", item.Source),
+	)
+}
+
+func (e *ApplyEntry) record(item *plan.Item, step *plan.Step, err error) {
+	msg := &common.ProgressMsg{
+		Source:      item.Source,
+		Destination: step.Result,
+		Profile:     step.Profile,
+		Err:         err,
+	}
+
+	e.Collector.Record(msg)
+	e.Interaction.Tick(msg)
+}
@@ -0,0 +1,244 @@
+package proxy
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/snivilised/cobrass/src/assistant/configuration"
+	"github.com/snivilised/extendio/xfs/nav"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/dupes"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+)
+
+// DupesEntry fingerprints every image found with the same traversal and
+// filters as shrink, then groups the duplicates among them. The callback
+// may be invoked by the worker pool, so the fingerprints are guarded.
+type DupesEntry struct {
+	EntryBase
+	Inputs *common.DupesCommandInputs
+	mx     sync.Mutex
+	prints []*dupes.Fingerprint
+}
+
+func (e *DupesEntry) principalFn(item *nav.TraverseItem) error {
+	fp, err := dupes.Take(e.Vfs, item.Path)
+	if err != nil {
+		// an unreadable file should not prevent the others being compared
+		//
+		e.Log.Warn("could not fingerprint item",
+			slog.String("path", item.Path),
+			slog.String("error", err.Error()),
+		)
+
+		return nil
+	}
+
+	e.mx.Lock()
+	defer e.mx.Unlock()
+
+	e.prints = append(e.prints, fp)
+
+	return nil
+}
+
+func (e *DupesEntry) ConfigureOptions(o *nav.TraverseOptions) {
+	e.EntryBase.ConfigureOptions(o)
+
+	o.Callback = &nav.LabelledTraverseCallback{
+		Label: "Dupes Entry Callback",
+		Fn:    e.principalFn,
+	}
+	o.Store.Subscription = nav.SubscribeFiles
+	o.Store.FilterDefs = e.FilterSetup.getDefs(e.FileManager.Finder().Statics())
+}
+
+func (e *DupesEntry) run() (*nav.TraverseResult, error) {
+	// dupes does not need to support resume
+	//
+	var nilResumption *nav.Resumption
+
+	result, err := e.navigateLegacy(
+		e.ConfigureOptions,
+		composeWith(e.Inputs.Root),
+		nilResumption,
+	)
+
+	if err != nil {
+		return result, err
+	}
+
+	native := e.Inputs.ParamSet.Native
+	report := dupes.Find(e.prints, int(native.Threshold), native.Exact)
+
+	if err := dupes.Render(os.Stdout, report); err != nil {
+		return result, err
+	}
+
+	if path := native.ReportPath; path != "" {
+		e.Log.Info("📝 writing dupes report", slog.String("path", path))
+
+		if err := e.write(path, report); err != nil {
+			return result, err
+		}
+	}
+
+	return result, e.resolve(report)
+}
+
+func (e *DupesEntry) write(path string, report *dupes.Report) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return e.Vfs.WriteFile(path, content, common.Permissions.Beezledub)
+}
+
+// resolve applies the requested action to the extras of the exact groups.
+// Near duplicates are only ever reported, since they are not the same
+// file, so it's up to the user to decide which of them to keep.
+func (e *DupesEntry) resolve(report *dupes.Report) error {
+	action := e.Inputs.ParamSet.Native.ActionEn.Value()
+
+	if action != common.DupesActionTrashEn && action != common.DupesActionLinkEn {
+		return nil
+	}
+
+	dryRun := e.Inputs.Root.PreviewFam.Native.DryRun
+
+	for _, group := range report.Groups {
+		if group.Kind != dupes.KindExact {
+			continue
+		}
+
+		for _, extra := range group.Extras {
+			e.Log.Info("resolve duplicate",
+				slog.String("action", common.DupesActionEnumInfo.NameOf(action)),
+				slog.String("extra", extra.Path),
+				slog.String("keeper", group.Keeper.Path),
+				slog.Bool("dry-run", dryRun),
+			)
+
+			if dryRun {
+				continue
+			}
+
+			var err error
+
+			switch action {
+			case common.DupesActionTrashEn:
+				err = e.trash(extra.Path)
+			case common.DupesActionLinkEn:
+				err = e.link(group.Keeper.Path, extra.Path)
+			case common.DupesActionNoneEn:
+			}
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// trash moves the extra into the trash folder alongside it, which is
+// marked, so that it's skipped on a re-run.
+func (e *DupesEntry) trash(extra string) error {
+	folder := filepath.Join(filepath.Dir(extra), e.FileManager.Finder().Statics().TrashTag())
+
+	if !e.Vfs.DirectoryExists(folder) {
+		if err := filing.MakeFolder(e.Vfs, folder); err != nil {
+			return errors.Wrapf(err, "could not create trash for '%v'", extra)
+		}
+	}
+
+	destination := filepath.Join(folder, filepath.Base(extra))
+
+	if e.Vfs.FileExists(destination) {
+		return errors.Errorf("could not trash '%v', '%v' already exists", extra, destination)
+	}
+
+	return e.Vfs.Rename(extra, destination)
+}
+
+// link replaces the extra with a hard link to the keeper. The link is
+// created alongside first, so that the extra is only replaced once the
+// link is known to be possible. An extra that is already linked to the
+// keeper, eg by a previous run, is left alone.
+func (e *DupesEntry) link(keeper, extra string) error {
+	if e.linked(keeper, extra) {
+		return nil
+	}
+
+	attempt := e.FileManager.Finder().Statics().AttemptLocation(extra)
+
+	if err := e.Vfs.Link(keeper, attempt); err != nil {
+		return errors.Wrapf(err, "could not link '%v' to '%v'", extra, keeper)
+	}
+
+	if err := e.Vfs.Rename(attempt, extra); err != nil {
+		_ = e.Vfs.Remove(attempt)
+
+		return errors.Wrapf(err, "could not replace '%v'", extra)
+	}
+
+	return nil
+}
+
+func (e *DupesEntry) linked(keeper, extra string) bool {
+	keeperInfo, err := e.Vfs.Lstat(keeper)
+	if err != nil {
+		return false
+	}
+
+	extraInfo, err := e.Vfs.Lstat(extra)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(keeperInfo, extraInfo)
+}
+
+type DupesParams struct {
+	Inputs *common.DupesCommandInputs
+	Viper  configuration.ViperConfig
+	Logger *slog.Logger
+	Vfs    storage.VirtualFS
+}
+
+func EnterDupes(
+	params *DupesParams,
+) (*nav.TraverseResult, error) {
+	configs := params.Inputs.Root.Configs
+	finder := filing.NewFinder(&filing.NewFinderInfo{
+		Advanced: configs.Advanced,
+		Filing:   configs.Filing,
+		Schemes:  configs.Schemes,
+		Arity:    1,
+	})
+
+	entry := &DupesEntry{
+		EntryBase: EntryBase{
+			Inputs:      params.Inputs.Root,
+			Viper:       params.Viper,
+			Log:         params.Logger,
+			Vfs:         params.Vfs,
+			FileManager: filing.NewManager(params.Vfs, finder, true),
+			FilterSetup: &filterSetup{
+				root:    params.Inputs.Root,
+				polyFam: params.Inputs.PolyFam,
+			},
+		},
+		Inputs: params.Inputs,
+		prints: []*dupes.Fingerprint{},
+	}
+
+	return entry.run()
+}
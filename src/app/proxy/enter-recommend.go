@@ -0,0 +1,59 @@
+package proxy
+
+import (
+	"log/slog"
+
+	"github.com/snivilised/cobrass/src/assistant/configuration"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+)
+
+type RecommendParams struct {
+	Inputs        *common.RecommendCommandInputs
+	Viper         configuration.ViperConfig
+	Logger        *slog.Logger
+	Vfs           storage.VirtualFS
+	Notifications *common.LifecycleNotifications
+}
+
+// EnterRecommend runs a sample shrink with every profile in the selected
+// scheme, then ranks the profiles by the bytes they saved weighed against
+// the quality of their results.
+func EnterRecommend(
+	params *RecommendParams,
+) ([]*report.Standing, error) {
+	shrink := params.Inputs.Shrink
+	shrink.Root.ParamSet.Native.IsSampling = true
+	shrink.Root.SamplingFam.Native.IsSampling = true
+	shrink.ParamSet.Native.OutputPath = params.Inputs.ParamSet.Native.OutputPath
+
+	entry, err := newShrinkEntry(&ShrinkParams{
+		Inputs:        shrink,
+		Viper:         params.Viper,
+		Logger:        params.Logger,
+		Vfs:           params.Vfs,
+		Notifications: params.Notifications,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err = entry.run(); err != nil {
+		return nil, err
+	}
+
+	standings := report.Rank(entry.Collector.Samples(), params.Vfs)
+
+	for _, standing := range standings {
+		params.Logger.Info("📊 profile standing",
+			slog.String("profile", standing.Profile),
+			slog.Int("files", standing.NoFiles),
+			slog.Float64("savings", standing.Savings()),
+			slog.Float64("ssim", standing.SSIM()),
+			slog.Float64("score", standing.Score()),
+		)
+	}
+
+	return standings, nil
+}
@@ -0,0 +1,160 @@
+package proxy
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/samber/lo"
+	"github.com/snivilised/cobrass/src/assistant/configuration"
+	"github.com/snivilised/extendio/xfs/nav"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+)
+
+// ReportEntry locates the results of a previous run, using the same
+// path-finder rules that were used to create them, so that they can be
+// compared side by side with their originals.
+type ReportEntry struct {
+	EntryBase
+	Inputs    *common.ReportCommandInputs
+	Collector *report.Collector
+	profiles  []string
+}
+
+func (e *ReportEntry) principalFn(item *nav.TraverseItem) error {
+	finder := e.FileManager.Finder()
+	sampling := e.Inputs.Root.SamplingFam.Native.IsSampling
+
+	for _, profile := range e.profiles {
+		pi := &common.PathInfo{
+			Item:       item,
+			Origin:     item.Extension.Parent,
+			Scheme:     finder.Scheme(),
+			Profile:    profile,
+			IsSampling: sampling,
+			Output:     e.Inputs.ParamSet.Native.OutputPath,
+		}
+
+		// When the input is transparent, the result took the place of the
+		// original, which was transferred out of the way, so the item being
+		// visited is actually the result.
+		//
+		source := item.Path
+
+		if finder.TransparentInput() && !sampling {
+			if folder, file := finder.Transfer(pi); folder != "" {
+				source = filepath.Join(folder, file)
+			}
+		}
+
+		folder, file := finder.Result(pi)
+		destination := filepath.Join(folder, file)
+
+		if source == destination || !e.Vfs.FileExists(source) || !e.Vfs.FileExists(destination) {
+			continue
+		}
+
+		e.Log.Debug("📝 report sample",
+			slog.String("source", source),
+			slog.String("destination", destination),
+			slog.String("profile", profile),
+		)
+
+		e.Collector.Record(&common.ProgressMsg{
+			Source:      source,
+			Destination: destination,
+			Scheme:      pi.Scheme,
+			Profile:     profile,
+		})
+	}
+
+	return nil
+}
+
+func (e *ReportEntry) ConfigureOptions(o *nav.TraverseOptions) {
+	e.EntryBase.ConfigureOptions(o)
+
+	o.Callback = &nav.LabelledTraverseCallback{
+		Label: "Report Entry Callback",
+		Fn:    e.principalFn,
+	}
+	o.Store.Subscription = nav.SubscribeFiles
+}
+
+func (e *ReportEntry) run() (*nav.TraverseResult, error) {
+	// report does not need to support resume
+	//
+	var nilResumption *nav.Resumption
+
+	result, err := e.navigateLegacy(
+		e.ConfigureOptions,
+		composeWith(e.Inputs.Root),
+		nilResumption,
+	)
+
+	if err != nil {
+		return result, err
+	}
+
+	path := e.Inputs.ParamSet.Native.ReportPath
+	if path == "" {
+		path = filepath.Join(
+			e.Inputs.Root.ParamSet.Native.Directory,
+			common.Definitions.Defaults.Report.Filename,
+		)
+	}
+
+	e.Log.Info("📝 writing report", slog.String("path", path))
+
+	return result, report.Write(path,
+		report.Build(common.Definitions.Pixa.AppName, e.Collector.Samples(), e.Vfs), e.Vfs,
+	)
+}
+
+type ReportParams struct {
+	Inputs *common.ReportCommandInputs
+	Viper  configuration.ViperConfig
+	Logger *slog.Logger
+	Vfs    storage.VirtualFS
+}
+
+func EnterReport(
+	params *ReportParams,
+) (*nav.TraverseResult, error) {
+	configs := params.Inputs.Root.Configs
+	selectedScheme := params.Inputs.Root.ProfileFam.Native.Scheme
+	scheme, _ := configs.Schemes.Scheme(selectedScheme)
+	profiles := lo.TernaryF(scheme == nil,
+		func() []string {
+			return []string{params.Inputs.Root.ProfileFam.Native.Profile}
+		},
+		func() []string {
+			return scheme.Profiles()
+		},
+	)
+	finder := filing.NewFinder(&filing.NewFinderInfo{
+		Advanced:   configs.Advanced,
+		Filing:     configs.Filing,
+		Schemes:    configs.Schemes,
+		Scheme:     selectedScheme,
+		OutputPath: params.Inputs.ParamSet.Native.OutputPath,
+		Arity:      uint(len(profiles)),
+	})
+
+	entry := &ReportEntry{
+		EntryBase: EntryBase{
+			Inputs:      params.Inputs.Root,
+			Viper:       params.Viper,
+			Log:         params.Logger,
+			Vfs:         params.Vfs,
+			FileManager: filing.NewManager(params.Vfs, finder, true),
+		},
+		Inputs:    params.Inputs,
+		Collector: report.NewCollector(),
+		profiles:  profiles,
+	}
+
+	return entry.run()
+}
@@ -1,25 +1,37 @@
 package proxy
 
 import (
-	"io/fs"
+	"bytes"
+	"fmt"
 	"log/slog"
-	"strings"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
 	"github.com/snivilised/cobrass/src/assistant/configuration"
 	"github.com/snivilised/extendio/xfs/nav"
 	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/extendio/xfs/utils"
 	"github.com/snivilised/pixa/src/app/proxy/common"
 	"github.com/snivilised/pixa/src/app/proxy/filing"
 	"github.com/snivilised/pixa/src/app/proxy/ipc"
 	"github.com/snivilised/pixa/src/app/proxy/orc"
+	"github.com/snivilised/pixa/src/app/proxy/plan"
+	"github.com/snivilised/pixa/src/app/proxy/report"
 	"github.com/snivilised/pixa/src/app/proxy/user"
+	"github.com/snivilised/pixa/src/locale"
 )
 
 type ShrinkEntry struct {
 	EntryBase
-	Inputs *common.ShrinkCommandInputs
+	Inputs    *common.ShrinkCommandInputs
+	Collector *report.Collector
+	Planner   *plan.Planner
+	Ledger    common.Ledger
+	Cache     *filing.Cache
+	journals  []string
 }
 
 func (e *ShrinkEntry) DiscoverOptionsFn(o *nav.TraverseOptions) {
@@ -27,10 +39,9 @@ func (e *ShrinkEntry) DiscoverOptionsFn(o *nav.TraverseOptions) {
 	o.Callback = &nav.LabelledTraverseCallback{
 		Label: "Discovery: Shrink Entry Callback",
 		Fn: func(item *nav.TraverseItem) error {
-			if strings.Contains(item.Path, e.FileManager.Finder().Statics().TrashTag()) {
-				return fs.SkipDir
-			}
-			journal := e.FileManager.Finder().JournalFullPath(item)
+			finder := e.FileManager.Finder()
+			journal := finder.JournalFullPath(item)
+			finder.Register(item)
 
 			presentation := lo.Ternary(e.Inputs.Root.TextualFam.Native.IsNoTui,
 				"🧩 linear", "💄 textual",
@@ -47,6 +58,8 @@ func (e *ShrinkEntry) DiscoverOptionsFn(o *nav.TraverseOptions) {
 				return nil
 			}
 
+			e.journals = append(e.journals, journal)
+
 			return e.FileManager.Create(journal, false)
 		},
 	}
@@ -70,10 +83,6 @@ func (e *ShrinkEntry) PrincipalOptionsFn(o *nav.TraverseOptions) {
 	o.Callback = e.EntryBase.Interaction.Decorate(&nav.LabelledTraverseCallback{
 		Label: "Principal: Shrink Entry Callback",
 		Fn: func(item *nav.TraverseItem) error {
-			if strings.Contains(item.Path, e.FileManager.Finder().Statics().TrashTag()) {
-				return fs.SkipDir
-			}
-
 			presentation := lo.Ternary(e.Inputs.Root.TextualFam.Native.IsNoTui,
 				"🧩 linear", "💄 textual",
 			)
@@ -85,6 +94,14 @@ func (e *ShrinkEntry) PrincipalOptionsFn(o *nav.TraverseOptions) {
 				slog.String("presentation", presentation),
 			)
 
+			if e.processed(item) {
+				e.Log.Info("skipping processed item",
+					slog.String("path", item.Path),
+				)
+
+				return e.FileManager.Remove(e.FileManager.Finder().JournalFullPath(item))
+			}
+
 			controller := e.Registry.Get()
 			defer e.Registry.Put(controller)
 
@@ -93,6 +110,15 @@ func (e *ShrinkEntry) PrincipalOptionsFn(o *nav.TraverseOptions) {
 	})
 }
 
+// processed determines whether the item should be skipped by an
+// incremental run, because it is a result of an earlier run that has not
+// changed since.
+func (e *ShrinkEntry) processed(item *nav.TraverseItem) bool {
+	native := e.Inputs.ParamSet.Native
+
+	return native.Incremental && !native.Force && e.Ledger.Processed(item.Path)
+}
+
 func (e *ShrinkEntry) ConfigureOptions(o *nav.TraverseOptions) {
 	e.EntryBase.ConfigureOptions(o)
 
@@ -113,10 +139,6 @@ func clearResumeFromWith(with nav.CreateNewRunnerWith) nav.CreateNewRunnerWith {
 }
 
 func (e *ShrinkEntry) resumeFn(item *nav.TraverseItem) error {
-	if strings.HasPrefix(item.Extension.Name, e.FileManager.Finder().Statics().TrashTag()) {
-		return fs.SkipDir
-	}
-
 	depth := item.Extension.Depth
 
 	e.Log.Debug("🎙️🎙️ Shrink Restore Callback",
@@ -144,6 +166,7 @@ func (e *ShrinkEntry) run() (result *nav.TraverseResult, err error) {
 		//
 		RestorePath: "/json-path-to-come-from-a-flag-option/restore.json",
 		Restorer: func(o *nav.TraverseOptions, _ *nav.ActiveState) {
+			e.ConfigureHooks(o)
 			o.Callback = &nav.LabelledTraverseCallback{
 				Label: "Resume Shrink Entry Callback",
 				Fn:    e.resumeFn,
@@ -159,20 +182,246 @@ func (e *ShrinkEntry) run() (result *nav.TraverseResult, err error) {
 		e.Inputs,
 	))
 
+	if err != nil {
+		e.discard()
+	}
+
+	if e.Inputs.ParamSet.Native.Flatten && !e.dry() {
+		// the names of flattened results may be decorated, so the mapping
+		// back to the originals is always recorded alongside them
+		//
+		path := filepath.Join(e.Inputs.ParamSet.Native.OutputPath,
+			common.Definitions.Defaults.Report.MappingFilename,
+		)
+
+		if mappingErr := report.WriteMappings(path,
+			report.Mappings(e.Collector.Samples()), e.Vfs,
+		); mappingErr != nil && err == nil {
+			err = mappingErr
+		}
+	}
+
+	if path := e.Inputs.ParamSet.Native.HTMLReport; path != "" && !e.dry() {
+		doc := report.Build(common.Definitions.Pixa.AppName, e.Collector.Samples(), e.Vfs)
+
+		if e.Inputs.ParamSet.Native.Flatten {
+			doc.Flatten()
+		}
+
+		if reportErr := report.Write(path, doc, e.Vfs); reportErr != nil {
+			e.Log.Error("could not write html report",
+				slog.String("path", path),
+				slog.String("error", reportErr.Error()),
+			)
+		}
+	}
+
 	return result, err
 }
 
+// dry determines whether the run has no effect on the real file system;
+// a simulation is not a dry run as far as the overlay is concerned, but
+// it is for anything outside of it.
+func (e *ShrinkEntry) dry() bool {
+	return e.Inputs.Root.PreviewFam.Native.DryRun || e.Inputs.Root.ParamSet.Native.Simulate
+}
+
+// discard removes the journal files of the items that were not reached,
+// because the run was aborted, so that they don't prevent a subsequent
+// run. The journal files of the items that were reached have already been
+// removed by the controller.
+func (e *ShrinkEntry) discard() {
+	for _, journal := range e.journals {
+		if err := e.FileManager.Remove(journal); err != nil {
+			e.Log.Error("could not remove journal file",
+				slog.String("path", journal),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// writePlan presents the plan of the dry run, to the plan file if one was
+// specified, otherwise to stdout. When planning for apply, the plan is
+// written as a document instead.
+func (e *ShrinkEntry) writePlan() error {
+	if path := e.Inputs.ParamSet.Native.Plan; path != "" {
+		doc, err := e.Planner.Document(e.Vfs, e.Inputs.Root.ParamSet.Native.Directory)
+		if err != nil {
+			return err
+		}
+
+		if err := plan.Write(e.Vfs, path, doc); err != nil {
+			return err
+		}
+
+		fmt.Printf("\n📋 plan of %v item(s) written to: '%v'\n", len(doc.Items), path)
+
+		return nil
+	}
+
+	path := e.Inputs.ParamSet.Native.PlanFile
+	if path == "" {
+		fmt.Println()
+
+		return e.Planner.Render(os.Stdout)
+	}
+
+	var buffer bytes.Buffer
+
+	if err := e.Planner.Render(&buffer); err != nil {
+		return err
+	}
+
+	return e.Vfs.WriteFile(path, buffer.Bytes(), common.Permissions.Beezledub)
+}
+
 type ShrinkParams struct {
 	Inputs        *common.ShrinkCommandInputs
 	Viper         configuration.ViperConfig
 	Logger        *slog.Logger
 	Vfs           storage.VirtualFS
 	Notifications *common.LifecycleNotifications
+	// Version of pixa, with which results are recorded
+	Version string
+	// CachePath is the location of the cache of results; there is no
+	// cache when empty
+	CachePath string
 }
 
 func EnterShrink(
 	params *ShrinkParams,
 ) (*nav.TraverseResult, error) {
+	if params.Inputs.Root.ParamSet.Native.Simulate {
+		return simulate(params)
+	}
+
+	return enterShrink(params)
+}
+
+func enterShrink(
+	params *ShrinkParams,
+) (*nav.TraverseResult, error) {
+	entry, err := newShrinkEntry(params)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := entry.run()
+
+	if entry.Cache != nil {
+		// the cache is only an optimisation, so failing to save it does not
+		// fail the run
+		//
+		if cacheErr := entry.Cache.Save(); cacheErr != nil {
+			params.Logger.Warn("could not save cache",
+				slog.String("path", params.CachePath),
+				slog.String("error", cacheErr.Error()),
+			)
+		}
+	}
+
+	if entry.Planner != nil {
+		if planErr := entry.writePlan(); planErr != nil && err == nil {
+			err = planErr
+		}
+	}
+
+	// the failures are summarised even when the run was aborted, as they
+	// are the reason why it was.
+	//
+	if summaryErr := summarise(params.Logger, entry.Collector); err == nil {
+		err = summaryErr
+	}
+
+	return result, err
+}
+
+// simulate performs the run against an overlay; an in-memory copy of the
+// directory tree, which the real file manager and path finder are free to
+// modify, with an agent that creates placeholder results. The changes made
+// to the overlay are then presented as a tree diff, before the overlay is
+// discarded.
+func simulate(params *ShrinkParams) (*nav.TraverseResult, error) {
+	var (
+		root     = params.Inputs.Root.ParamSet.Native
+		shrink   = params.Inputs.ParamSet.Native
+		preview  = params.Inputs.Root.PreviewFam.Native
+		overlay  = plan.NewOverlay(params.Vfs, storage.UseMemFS())
+		overlaid = *params
+		before   plan.Snapshot
+		err      error
+	)
+
+	directory, output, trash := root.Directory,
+		resolve(shrink.OutputPath), resolve(shrink.TrashPath)
+	roots := lo.Uniq(lo.Filter([]string{directory, output, trash}, func(path string, _ int) bool {
+		return path != ""
+	}))
+
+	if err = overlay.Mirror(directory); err != nil {
+		return nil, err
+	}
+
+	if before, err = overlay.Snapshot(roots...); err != nil {
+		return nil, err
+	}
+
+	// as far as the overlay is concerned, this is not a dry run
+	//
+	specifiedOutput, specifiedTrash := shrink.OutputPath, shrink.TrashPath
+	shrink.OutputPath, shrink.TrashPath, preview.DryRun = output, trash, false
+	overlaid.Vfs = overlay.Vfs
+
+	defer func() {
+		shrink.OutputPath, shrink.TrashPath, preview.DryRun =
+			specifiedOutput, specifiedTrash, true
+	}()
+
+	result, err := enterShrink(&overlaid)
+
+	after, snapshotErr := overlay.Snapshot(roots...)
+	if snapshotErr != nil {
+		return result, lo.Ternary(err != nil, err, snapshotErr)
+	}
+
+	fmt.Println()
+
+	if renderErr := plan.RenderDiff(os.Stdout, roots, plan.Diff(before, after)); renderErr != nil && err == nil {
+		err = renderErr
+	}
+
+	return result, err
+}
+
+// resolve makes the path absolute, so that it can be redirected into
+// an overlay.
+func resolve(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	return utils.ResolvePath(path)
+}
+
+// summarise reports the failures of the run, if there are any, returning
+// the corresponding error.
+func summarise(logger *slog.Logger, collector *report.Collector) error {
+	failures := collector.Failures()
+	if failures.Failed == 0 {
+		return nil
+	}
+
+	logger.Warn("run completed with failures",
+		slog.Int("failed", failures.Failed),
+		slog.Int("total", failures.Total),
+	)
+	report.Summarise(os.Stderr, failures)
+
+	return locale.NewPartialFailureError(failures.Failed, failures.Total)
+}
+
+func newShrinkEntry(params *ShrinkParams) (*ShrinkEntry, error) {
 	var (
 		agent common.ExecutionAgent
 		err   error
@@ -191,10 +440,12 @@ func EnterShrink(
 	)
 	finder := filing.NewFinder(&filing.NewFinderInfo{
 		Advanced:   params.Inputs.Root.Configs.Advanced,
+		Filing:     params.Inputs.Root.Configs.Filing,
 		Schemes:    schemes,
 		Scheme:     selectedScheme,
 		OutputPath: params.Inputs.ParamSet.Native.OutputPath,
 		TrashPath:  params.Inputs.ParamSet.Native.TrashPath,
+		Flatten:    params.Inputs.ParamSet.Native.Flatten,
 		Observer:   params.Inputs.Root.Observers.PathFinder,
 		Arity:      arity,
 	})
@@ -206,17 +457,15 @@ func EnterShrink(
 		params.Inputs.Root.Configs.Advanced,
 		params.Inputs.ParamSet.Native.KnownBy,
 		fileManager,
-		params.Inputs.Root.PreviewFam.Native.DryRun,
+		params.Inputs.Root.PreviewFam.Native.DryRun || params.Inputs.Root.ParamSet.Native.Simulate,
 	); err != nil {
-		if errors.Is(err, ipc.ErrUseDummyExecutor) {
-			params.Logger.Warn("===> 💥💥💥 REVERTING TO DUMMY EXECUTOR !!!!")
-
-			agent = ipc.Pacify(
-				params.Inputs.Root.Configs.Advanced,
-				params.Inputs.ParamSet.Native.KnownBy,
-				fileManager,
-				ipc.PacifyWithDummy,
+		if errors.Is(err, ipc.ErrExecutableNotFound) {
+			program := params.Inputs.Root.Configs.Advanced.Executable().Symbol()
+			params.Logger.Error("executable not found",
+				slog.String("name", program),
 			)
+
+			return nil, locale.NewMissingExecutableError(program)
 		} else if errors.Is(err, ipc.ErrUnsupportedExecutor) {
 			params.Logger.Error("===> 💥💥💥 Undefined EXECUTOR: '%v' !!!!",
 				slog.String("name", params.Inputs.Root.Configs.Advanced.Executable().Symbol()),
@@ -231,6 +480,40 @@ func EnterShrink(
 		params.Logger,
 		arity,
 	)
+	collector := report.NewCollector()
+
+	var (
+		planner *plan.Planner
+		planned common.Planner // must remain nil, unless there is a planner
+	)
+
+	if params.Inputs.Root.PreviewFam.Native.DryRun {
+		planner = plan.NewPlanner()
+		planned = planner
+	}
+
+	ledger := filing.NewLedger(params.Vfs, params.Version)
+
+	var (
+		cache  *filing.Cache
+		cached common.Cache // must remain nil, unless there is a cache
+	)
+
+	// a dry run produces no results and the results of a simulation are
+	// discarded, so neither can be cached
+	//
+	if params.CachePath != "" && !params.Inputs.Root.PreviewFam.Native.DryRun &&
+		!params.Inputs.Root.ParamSet.Native.Simulate {
+		if cache, err = filing.NewCache(params.Vfs, params.CachePath); err != nil {
+			params.Logger.Warn("could not load cache",
+				slog.String("path", params.CachePath),
+				slog.String("error", err.Error()),
+			)
+		} else {
+			cached = cache
+		}
+	}
+
 	entry := &ShrinkEntry{
 		EntryBase: EntryBase{
 			Inputs:      params.Inputs.Root,
@@ -241,20 +524,30 @@ func EnterShrink(
 			Vfs:         params.Vfs,
 			FileManager: fileManager,
 			FilterSetup: &filterSetup{
-				inputs: params.Inputs,
+				root:    params.Inputs.Root,
+				polyFam: params.Inputs.PolyFam,
 			},
 			Registry: orc.NewRegistry(&common.SessionControllerInfo{
 				Agent:       agent,
 				Inputs:      params.Inputs,
 				FileManager: fileManager,
 				Interaction: interaction,
+				Recorder:    collector,
+				Failures:    &atomic.Uint32{},
+				Planner:     planned,
+				Ledger:      ledger,
+				Cache:       cached,
 			},
 				params.Inputs.Root.Configs,
 			),
 			Notifications: params.Notifications,
 		},
-		Inputs: params.Inputs,
+		Inputs:    params.Inputs,
+		Collector: collector,
+		Planner:   planner,
+		Ledger:    ledger,
+		Cache:     cache,
 	}
 
-	return entry.run()
+	return entry, nil
 }
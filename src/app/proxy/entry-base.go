@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"log/slog"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/samber/lo"
@@ -14,6 +15,7 @@ import (
 	"github.com/snivilised/extendio/xfs/storage"
 	"github.com/snivilised/lorax/boost"
 	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
 	"github.com/snivilised/pixa/src/app/proxy/orc"
 )
 
@@ -38,31 +40,7 @@ type EntryBase struct {
 
 func (e *EntryBase) ConfigureOptions(o *nav.TraverseOptions) {
 	e.Options = o
-	o.Hooks.QueryStatus = func(path string) (os.FileInfo, error) {
-		fi, err := e.Vfs.Lstat(path)
-
-		return fi, err
-	}
-	o.Hooks.ReadDirectory = func(dirname string) ([]fs.DirEntry, error) {
-		contents, err := e.Vfs.ReadDir(dirname)
-		if err != nil {
-			return nil, err
-		}
-
-		statics := e.FileManager.Finder().Statics()
-		jWithoutExt := statics.Journal.WithoutExt
-		trash := statics.TrashTag()
-		sample := fmt.Sprintf("$%v$", statics.Sample) // PathFinder.FileSupplement
-
-		return lo.Filter(contents, func(item fs.DirEntry, _ int) bool {
-			name := item.Name()
-
-			return !strings.HasPrefix(name, ".") &&
-				!strings.Contains(name, jWithoutExt) &&
-				!strings.Contains(name, trash) &&
-				!strings.Contains(name, sample)
-		}), nil
-	}
+	e.ConfigureHooks(o)
 
 	if o.Store.FilterDefs == nil {
 		switch {
@@ -142,6 +120,53 @@ func (e *EntryBase) ConfigureOptions(o *nav.TraverseOptions) {
 	o.Monitor.Log = e.Log
 }
 
+// ConfigureHooks installs the hooks through which the navigator accesses the
+// file system. This is where the entries that pixa must never process, such
+// as its own journals and the folders it created in earlier runs, are
+// filtered out, so that every traversal, including a resumed one, honours
+// them.
+func (e *EntryBase) ConfigureHooks(o *nav.TraverseOptions) {
+	o.Hooks.QueryStatus = func(path string) (os.FileInfo, error) {
+		fi, err := e.Vfs.Lstat(path)
+
+		return fi, err
+	}
+	o.Hooks.ReadDirectory = func(dirname string) ([]fs.DirEntry, error) {
+		contents, err := e.Vfs.ReadDir(dirname)
+		if err != nil {
+			return nil, err
+		}
+
+		statics := e.FileManager.Finder().Statics()
+		jWithoutExt := statics.Journal.WithoutExt
+		trash := statics.TrashTag()
+		dejaVu := statics.DejaVuTag()
+		failed := statics.FailedTag()
+		sample := fmt.Sprintf("$%v$", statics.Sample) // PathFinder.FileSupplement
+
+		// folders created by pixa in an earlier run are recognised by their
+		// DEJA-VU marker, since the labels may have been configured
+		// differently since. Folders created by versions of pixa that
+		// pre-date the marker are unmarked, so the labels still apply to
+		// folders as well as files.
+		//
+		return lo.Filter(contents, func(item fs.DirEntry, _ int) bool {
+			name := item.Name()
+
+			if item.IsDir() && filing.DejaVu(e.Vfs, filepath.Join(dirname, name)) {
+				return false
+			}
+
+			return !strings.HasPrefix(name, ".") &&
+				!strings.Contains(name, jWithoutExt) &&
+				!strings.Contains(name, trash) &&
+				!strings.Contains(name, dejaVu) &&
+				!strings.Contains(name, failed) &&
+				!strings.Contains(name, sample)
+		}), nil
+	}
+}
+
 func (e *EntryBase) navigateLegacy(
 	optionsFn nav.TraverseOptionFn,
 	with nav.CreateNewRunnerWith,
@@ -0,0 +1,142 @@
+package filing
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// CacheVersion is the version of the format of the cache file
+const CacheVersion = 1
+
+// NewCache creates a Cache that persists at path, loading the entries
+// recorded by earlier runs, if there are any.
+func NewCache(vfs storage.VirtualFS, path string) (*Cache, error) {
+	c := &Cache{
+		vfs:  vfs,
+		path: path,
+		content: cacheContent{
+			Version: CacheVersion,
+			Entries: map[string]map[string]*common.CachedResult{},
+		},
+	}
+
+	if !vfs.FileExists(path) {
+		return c, nil
+	}
+
+	data, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &c.content); err != nil {
+		return nil, err
+	}
+
+	if c.content.Version != CacheVersion || c.content.Entries == nil {
+		// the entries of an incompatible version can't be relied upon,
+		// so they are discarded; they are only an optimisation.
+		//
+		c.content.Version = CacheVersion
+		c.content.Entries = map[string]map[string]*common.CachedResult{}
+	}
+
+	return c, nil
+}
+
+// Cache is the index of results, held in a json file in the pixa data
+// directory. The entries are keyed by the content hash of the input,
+// then by profile. It is accessed concurrently by the workers, so access
+// is guarded.
+type Cache struct {
+	vfs     storage.VirtualFS
+	path    string
+	mx      sync.Mutex
+	content cacheContent
+	dirty   bool
+}
+
+type cacheContent struct {
+	Version int                                        `json:"version"`
+	Entries map[string]map[string]*common.CachedResult `json:"entries"`
+}
+
+// Lookup returns the earlier result of the input for the profile. A result
+// is only returned when it was produced by the same command and it still
+// exists with the content it was recorded with; otherwise it may have been
+// edited or replaced since.
+func (c *Cache) Lookup(input, profile, command string) (result, hash string, err error) {
+	if hash, err = ContentHash(c.vfs, input); err != nil {
+		return "", "", err
+	}
+
+	c.mx.Lock()
+	entry := c.content.Entries[hash][profile]
+	c.mx.Unlock()
+
+	if entry == nil || entry.Command != command || !c.vfs.FileExists(entry.Result) {
+		return "", hash, nil
+	}
+
+	if current, err := ContentHash(c.vfs, entry.Result); err != nil || current != entry.Hash {
+		return "", hash, nil //nolint:nilerr // a result that can't be read is just a miss
+	}
+
+	return entry.Result, hash, nil
+}
+
+// Record adds the result of the input with the hash, replacing any earlier
+// result for the profile.
+func (c *Cache) Record(hash, profile, command, result string) error {
+	current, err := ContentHash(c.vfs, result)
+	if err != nil {
+		return err
+	}
+
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if _, found := c.content.Entries[hash]; !found {
+		c.content.Entries[hash] = map[string]*common.CachedResult{}
+	}
+
+	c.content.Entries[hash][profile] = &common.CachedResult{
+		Result:  result,
+		Hash:    current,
+		Command: command,
+	}
+	c.dirty = true
+
+	return nil
+}
+
+// Save writes the cache to its file, if anything has been recorded.
+func (c *Cache) Save() error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	if !c.dirty {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(c.content, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := c.vfs.MkdirAll(filepath.Dir(c.path), perm); err != nil {
+		return err
+	}
+
+	if err := c.vfs.WriteFile(c.path, data, beezledub); err != nil {
+		return err
+	}
+
+	c.dirty = false
+
+	return nil
+}
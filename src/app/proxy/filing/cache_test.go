@@ -0,0 +1,90 @@
+package filing_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+)
+
+var _ = Describe("Cache", func() {
+	const (
+		command = "magick -strip -quality 85"
+	)
+
+	var (
+		vfs              storage.VirtualFS
+		path             string
+		input, duplicate string
+		result           string
+		hash             string
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		directory := GinkgoT().TempDir()
+		path = filepath.Join(GinkgoT().TempDir(), "pixa", "pixa-cache.json")
+		input = filepath.Join(directory, "a.jpg")
+		duplicate = filepath.Join(directory, "copy-of-a.jpg")
+		result = filepath.Join(directory, "web", "a.jpg")
+
+		Expect(vfs.MkdirAll(filepath.Dir(result), common.Permissions.Write)).To(Succeed())
+		for _, file := range []string{input, duplicate} {
+			Expect(vfs.WriteFile(file, []byte("original"), common.Permissions.Beezledub)).To(Succeed())
+		}
+		Expect(vfs.WriteFile(result, []byte("shrunk"), common.Permissions.Beezledub)).To(Succeed())
+
+		cache, err := filing.NewCache(vfs, path)
+		Expect(err).To(Succeed())
+
+		_, hash, err = cache.Lookup(input, "blur", command)
+		Expect(err).To(Succeed())
+		Expect(cache.Record(hash, "blur", command, result)).To(Succeed())
+		Expect(cache.Save()).To(Succeed())
+	})
+
+	Context("given: duplicate of cached input", func() {
+		It("🧪 should: find result recorded by earlier run", func() {
+			cache, err := filing.NewCache(vfs, path)
+			Expect(err).To(Succeed())
+
+			found, duplicateHash, err := cache.Lookup(duplicate, "blur", command)
+			Expect(err).To(Succeed())
+			Expect(found).To(Equal(result))
+			Expect(duplicateHash).To(Equal(hash))
+		})
+	})
+
+	Context("given: different profile", func() {
+		It("🧪 should: not find result", func() {
+			cache, _ := filing.NewCache(vfs, path)
+			found, _, err := cache.Lookup(duplicate, "sf", command)
+			Expect(err).To(Succeed())
+			Expect(found).To(BeEmpty())
+		})
+	})
+
+	Context("given: different command", func() {
+		It("🧪 should: not find result", func() {
+			cache, _ := filing.NewCache(vfs, path)
+			found, _, err := cache.Lookup(duplicate, "blur", "magick -quality 50")
+			Expect(err).To(Succeed())
+			Expect(found).To(BeEmpty())
+		})
+	})
+
+	Context("given: result modified since recorded", func() {
+		It("🧪 should: not find result", func() {
+			Expect(vfs.WriteFile(result, []byte("edited"), common.Permissions.Beezledub)).To(Succeed())
+
+			cache, _ := filing.NewCache(vfs, path)
+			found, _, err := cache.Lookup(duplicate, "blur", command)
+			Expect(err).To(Succeed())
+			Expect(found).To(BeEmpty())
+		})
+	})
+})
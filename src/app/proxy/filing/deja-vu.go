@@ -0,0 +1,36 @@
+package filing
+
+import (
+	"path/filepath"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// MakeFolder creates the folder, along with any missing parents, leaving
+// the DEJA-VU marker in the folder, if it did not already exist. The
+// marker identifies the folder as one that pixa created to hold its own
+// outputs (trash, failed, results and supplements), so that a subsequent
+// run over the same tree does not feed them back in, regardless of how
+// the labels that name them are configured at the time. Only the folder
+// itself is marked, not its parents, since they may be the user's (eg, a
+// parent of the output), whose content must remain eligible.
+func MakeFolder(vfs storage.VirtualFS, path string) error {
+	if vfs.DirectoryExists(path) {
+		return nil
+	}
+
+	if err := vfs.MkdirAll(path, perm); err != nil {
+		return err
+	}
+
+	return vfs.WriteFile(
+		filepath.Join(path, common.Definitions.Filing.DejaVu), []byte{}, beezledub,
+	)
+}
+
+// DejaVu determines whether the folder was created by pixa, by the
+// presence of its marker.
+func DejaVu(vfs storage.VirtualFS, folder string) bool {
+	return vfs.FileExists(filepath.Join(folder, common.Definitions.Filing.DejaVu))
+}
@@ -45,6 +45,10 @@ func (fm *FileManager) DirectoryExists(pathAt string) bool {
 }
 
 func (fm *FileManager) Create(path string, overwrite bool) error {
+	if fm.dryRun {
+		return nil
+	}
+
 	if fm.Vfs.FileExists(path) && !overwrite {
 		return errors.Wrapf(os.ErrExist, "could not create file at path: '%v'", path)
 	}
@@ -60,6 +64,17 @@ func (fm *FileManager) Create(path string, overwrite bool) error {
 	return nil
 }
 
+// EnsureFolder creates the folder, along with any missing parents, if it
+// does not already exist; eg the folder of a result, which depends on the
+// layout of the result template.
+func (fm *FileManager) EnsureFolder(path string) error {
+	if fm.dryRun || fm.Vfs.DirectoryExists(path) {
+		return nil
+	}
+
+	return MakeFolder(fm.Vfs, path)
+}
+
 // Setup prepares for operation by moving existing file out of the way,
 // if applicable. Return the path denoting where the input will be moved to.
 func (fm *FileManager) Setup(pi *common.PathInfo) (destination string, err error) {
@@ -76,9 +91,14 @@ func (fm *FileManager) Setup(pi *common.PathInfo) (destination string, err error
 	// original alone and create other outputs; in this scenario
 	// we don't want to rename/move the source...
 	//
+	// When there is nothing to transfer (eg, a sample), the input stays
+	// where it is.
+	//
+	destination = pi.Item.Path
+
 	if folder, file := fm.finder.Transfer(pi); folder != "" {
 		if !fm.dryRun {
-			if err = fm.Vfs.MkdirAll(folder, perm); err != nil {
+			if err = MakeFolder(fm.Vfs, folder); err != nil {
 				return errorDestination, errors.Wrapf(
 					err, "could not create parent setup for '%v'", pi.Item.Path,
 				)
@@ -113,6 +133,122 @@ func (fm *FileManager) Setup(pi *common.PathInfo) (destination string, err error
 	return destination, nil
 }
 
+func (fm *FileManager) ReadFile(path string) ([]byte, error) {
+	return fm.Vfs.ReadFile(path)
+}
+
+func (fm *FileManager) FileSize(path string) (int64, error) {
+	info, err := fm.Vfs.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
+
+// Move renames the file, replacing the destination if it already exists.
+func (fm *FileManager) Move(from, to string) error {
+	if fm.dryRun {
+		return nil
+	}
+
+	if fm.Vfs.FileExists(to) {
+		if err := fm.Vfs.Remove(to); err != nil {
+			return errors.Wrapf(err, "could not replace '%v'", to)
+		}
+	}
+
+	return fm.Vfs.Rename(from, to)
+}
+
+func (fm *FileManager) Remove(path string) error {
+	if fm.dryRun || !fm.Vfs.FileExists(path) {
+		return nil
+	}
+
+	return fm.Vfs.Remove(path)
+}
+
+// Reject discards a result that is not fit for purpose. If the result
+// took the place of the input, then the input is moved back from where
+// it was transferred to during setup, so that the original is kept.
+func (fm *FileManager) Reject(pi *common.PathInfo, destination string) error {
+	if fm.dryRun {
+		return nil
+	}
+
+	if fm.Vfs.FileExists(destination) {
+		if err := fm.Vfs.Remove(destination); err != nil {
+			return errors.Wrapf(err, "could not remove rejected result '%v'", destination)
+		}
+	}
+
+	source := pi.RunStep.Source
+
+	if destination == pi.Item.Path && source != destination && fm.Vfs.FileExists(source) {
+		if err := fm.Vfs.Rename(source, destination); err != nil {
+			return errors.Wrapf(err, "could not restore input '%v'", destination)
+		}
+	}
+
+	return nil
+}
+
+// Quarantine moves the input of a failed item into the failed folder,
+// along with a sidecar file containing the reason it failed, so that it
+// can be inspected without holding up the rest of the run. If setup moved
+// the input out of the way, then it is quarantined from there.
+func (fm *FileManager) Quarantine(pi *common.PathInfo, reason error) error {
+	if fm.dryRun {
+		return nil
+	}
+
+	source := pi.RunStep.Source
+	if source == "" || !fm.Vfs.FileExists(source) {
+		source = pi.Item.Path
+	}
+
+	if !fm.Vfs.FileExists(source) {
+		return fmt.Errorf("could not quarantine, source file: '%v' does not exist", source)
+	}
+
+	folder, file := fm.finder.Quarantine(pi)
+
+	if err := MakeFolder(fm.Vfs, folder); err != nil {
+		return errors.Wrapf(err, "could not create quarantine folder for '%v'", pi.Item.Path)
+	}
+
+	destination := filepath.Join(folder, file)
+
+	if err := fm.Move(source, destination); err != nil {
+		return errors.Wrapf(err, "could not quarantine '%v'", pi.Item.Path)
+	}
+
+	return fm.Vfs.WriteFile(fm.finder.Statics().ErrorSidecar(destination),
+		[]byte(reason.Error()+"\n"), beezledub,
+	)
+}
+
+// Reuse puts a copy of an earlier result in place of a new one, as a hard
+// link if requested. A hard link is not possible across file systems, in
+// which case the result is copied instead.
+func (fm *FileManager) Reuse(from, to string, link bool) error {
+	if fm.dryRun {
+		return nil
+	}
+
+	if link && fm.Vfs.Link(from, to) == nil {
+		return nil
+	}
+
+	content, err := fm.Vfs.ReadFile(from)
+	if err != nil {
+		return errors.Wrapf(err, "could not reuse '%v'", from)
+	}
+
+	return fm.Vfs.WriteFile(to, content, beezledub)
+}
+
 func (fm *FileManager) Tidy(pi *common.PathInfo) error {
 	if fm.dryRun {
 		return nil
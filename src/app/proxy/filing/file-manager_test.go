@@ -0,0 +1,162 @@
+package filing_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/nav"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+)
+
+var _ = Describe("FileManager", func() {
+	var (
+		vfs    storage.VirtualFS
+		finder common.PathFinder
+		origin string
+		pi     *common.PathInfo
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		origin = GinkgoT().TempDir()
+		finder = filing.NewFinder(&filing.NewFinderInfo{
+			Advanced: &cfg.MsAdvancedConfig{
+				LabelsCFG: cfg.MsLabelsConfig{
+					Journal: "journal",
+					Trash:   "TRASH",
+					Failed:  "FAILED",
+				},
+			},
+			Schemes: &cfg.MsSchemesConfig{},
+			Arity:   1,
+		})
+
+		name := "01_Backyard-Worlds-Planet-9_s01.jpg"
+		pi = &common.PathInfo{
+			Item: &nav.TraverseItem{
+				Path: filepath.Join(origin, name),
+				Extension: nav.ExtendedItem{
+					Name: name,
+				},
+			},
+			Origin: origin,
+		}
+
+		Expect(vfs.WriteFile(pi.Item.Path, []byte("input"), common.Permissions.Beezledub)).To(Succeed())
+	})
+
+	Context("Create", func() {
+		When("dry run", func() {
+			It("🧪 should: not create file", func() {
+				manager := filing.NewManager(vfs, finder, true)
+				path := filepath.Join(origin, "result.jpg")
+				Expect(manager.Create(path, true)).To(Succeed())
+				Expect(vfs.FileExists(path)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("EnsureFolder", func() {
+		It("🧪 should: create missing folder with parents", func() {
+			manager := filing.NewManager(vfs, finder, false)
+			folder := filepath.Join(origin, "web", "jpg")
+			Expect(manager.EnsureFolder(folder)).To(Succeed())
+			Expect(vfs.DirectoryExists(folder)).To(BeTrue())
+		})
+
+		It("🧪 should: mark only the folder it created, not its parents", func() {
+			manager := filing.NewManager(vfs, finder, false)
+			Expect(manager.EnsureFolder(filepath.Join(origin, "web", "jpg"))).To(Succeed())
+			Expect(filing.DejaVu(vfs, filepath.Join(origin, "web", "jpg"))).To(BeTrue())
+			Expect(filing.DejaVu(vfs, filepath.Join(origin, "web"))).To(BeFalse())
+			Expect(filing.DejaVu(vfs, origin)).To(BeFalse())
+		})
+
+		It("🧪 should: not mark an existing folder", func() {
+			manager := filing.NewManager(vfs, finder, false)
+			folder := filepath.Join(origin, "exports")
+			Expect(vfs.MkdirAll(folder, common.Permissions.Write)).To(Succeed())
+			Expect(manager.EnsureFolder(folder)).To(Succeed())
+			Expect(filing.DejaVu(vfs, folder)).To(BeFalse())
+		})
+
+		When("dry run", func() {
+			It("🧪 should: not create folder", func() {
+				manager := filing.NewManager(vfs, finder, true)
+				folder := filepath.Join(origin, "web")
+				Expect(manager.EnsureFolder(folder)).To(Succeed())
+				Expect(vfs.DirectoryExists(folder)).To(BeFalse())
+			})
+		})
+	})
+
+	Context("Quarantine", func() {
+		It("🧪 should: move input into failed folder with error sidecar", func() {
+			manager := filing.NewManager(vfs, finder, false)
+			Expect(manager.Quarantine(pi, errors.New("exit status 1"))).To(Succeed())
+
+			destination := filepath.Join(origin, "$FAILED$", pi.Item.Extension.Name)
+			Expect(vfs.FileExists(pi.Item.Path)).To(BeFalse())
+			Expect(vfs.FileExists(destination)).To(BeTrue())
+			Expect(filing.DejaVu(vfs, filepath.Dir(destination))).To(BeTrue())
+
+			reason, err := vfs.ReadFile(destination + ".error.txt")
+			Expect(err).To(Succeed())
+			Expect(string(reason)).To(Equal("exit status 1\n"))
+		})
+
+		It("🧪 should: quarantine input from where setup moved it", func() {
+			pi.RunStep.Source = filepath.Join(origin, "moved.jpg")
+			Expect(vfs.Rename(pi.Item.Path, pi.RunStep.Source)).To(Succeed())
+
+			manager := filing.NewManager(vfs, finder, false)
+			Expect(manager.Quarantine(pi, errors.New("timed out"))).To(Succeed())
+
+			Expect(vfs.FileExists(pi.RunStep.Source)).To(BeFalse())
+			Expect(vfs.FileExists(filepath.Join(origin, "$FAILED$", pi.Item.Extension.Name))).To(BeTrue())
+		})
+
+		When("dry run", func() {
+			It("🧪 should: leave input in place", func() {
+				manager := filing.NewManager(vfs, finder, true)
+				Expect(manager.Quarantine(pi, errors.New("exit status 1"))).To(Succeed())
+
+				Expect(vfs.FileExists(pi.Item.Path)).To(BeTrue())
+				Expect(vfs.DirectoryExists(filepath.Join(origin, "$FAILED$"))).To(BeFalse())
+			})
+		})
+	})
+
+	Context("Reuse", func() {
+		It("🧪 should: hard link result", func() {
+			manager := filing.NewManager(vfs, finder, false)
+			destination := filepath.Join(origin, "reused.jpg")
+			Expect(manager.Reuse(pi.Item.Path, destination, true)).To(Succeed())
+
+			original, _ := vfs.Stat(pi.Item.Path)
+			reused, _ := vfs.Stat(destination)
+			Expect(os.SameFile(original, reused)).To(BeTrue())
+		})
+
+		It("🧪 should: copy result", func() {
+			manager := filing.NewManager(vfs, finder, false)
+			destination := filepath.Join(origin, "reused.jpg")
+			Expect(manager.Reuse(pi.Item.Path, destination, false)).To(Succeed())
+
+			original, _ := vfs.Stat(pi.Item.Path)
+			reused, _ := vfs.Stat(destination)
+			Expect(os.SameFile(original, reused)).To(BeFalse())
+
+			content, err := vfs.ReadFile(destination)
+			Expect(err).To(Succeed())
+			Expect(string(content)).To(Equal("input"))
+		})
+	})
+})
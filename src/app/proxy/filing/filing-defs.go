@@ -1,10 +1,13 @@
 package filing
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"path"
 	"path/filepath"
 	"strings"
 
+	"github.com/snivilised/extendio/xfs/storage"
 	"github.com/snivilised/pixa/src/app/proxy/common"
 )
 
@@ -21,3 +24,16 @@ func SupplementFilename(name, supp string, statics *common.StaticInfo) string {
 func SupplementFolder(directory, supp string) string {
 	return filepath.Join(directory, supp)
 }
+
+// ContentHash returns the hash of the content of the file at path, by which
+// the file is recognised, regardless of its name or location.
+func ContentHash(vfs storage.VirtualFS, path string) (string, error) {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,64 @@
+package filing
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	// disambiguatorLength is the number of hex digits of the hash of the
+	// sub-path, used to tell apart the results of inputs with the same name
+	disambiguatorLength = 8
+	// clash is the number of sub-paths a name must be found in to clash
+	clash = 2
+)
+
+// NewFlattener creates a Flattener.
+func NewFlattener() *Flattener {
+	return &Flattener{
+		names: make(map[string]map[string]bool),
+	}
+}
+
+// Flattener disambiguates the names of results that are flattened into a
+// single folder, when inputs of the same name are found in different
+// folders. The names are registered during discovery, so every clash is
+// known before any result is created, which means the name of a result
+// does not depend on the order in which the items are processed. Discovery
+// may be running with a worker pool, so access is guarded.
+type Flattener struct {
+	mx    sync.Mutex
+	names map[string]map[string]bool // name -> sub-paths it is found in
+}
+
+// Register records that the name is found in the sub-path.
+func (fl *Flattener) Register(subPath, name string) {
+	fl.mx.Lock()
+	defer fl.mx.Unlock()
+
+	if _, found := fl.names[name]; !found {
+		fl.names[name] = make(map[string]bool)
+	}
+
+	fl.names[name][subPath] = true
+}
+
+// Name returns the name of the flattened result. The name is retained,
+// unless it's found in more than one sub-path, in which case it's
+// decorated with a short hash of the sub-path.
+func (fl *Flattener) Name(subPath, name string) string {
+	fl.mx.Lock()
+	defer fl.mx.Unlock()
+
+	if len(fl.names[name]) < clash {
+		return name
+	}
+
+	sum := sha256.Sum256([]byte(filepath.ToSlash(subPath)))
+
+	return FilenameWithoutExtension(name) + "." +
+		hex.EncodeToString(sum[:])[:disambiguatorLength] + path.Ext(name)
+}
@@ -0,0 +1,89 @@
+package filing_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/nav"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+)
+
+var _ = Describe("Flattener", func() {
+	var (
+		finder common.PathFinder
+		output string
+	)
+
+	item := func(subPath, name string) *nav.TraverseItem {
+		return &nav.TraverseItem{
+			Path: filepath.Join("pics", subPath, name),
+			Extension: nav.ExtendedItem{
+				Name:    name,
+				SubPath: subPath,
+			},
+		}
+	}
+
+	result := func(item *nav.TraverseItem) (folder, file string) {
+		return finder.Result(&common.PathInfo{
+			Item:   item,
+			Origin: filepath.Dir(item.Path),
+			Output: output,
+		})
+	}
+
+	BeforeEach(func() {
+		output = filepath.Join("web", "images")
+		finder = filing.NewFinder(&filing.NewFinderInfo{
+			Advanced: &cfg.MsAdvancedConfig{
+				LabelsCFG: cfg.MsLabelsConfig{
+					Adhoc: "ADHOC",
+				},
+				ExtensionsCFG: cfg.MsExtensionsConfig{
+					TransformsCSV: "lower",
+					Remap: map[string]string{
+						"jpeg": "jpg",
+					},
+				},
+			},
+			Schemes:    &cfg.MsSchemesConfig{},
+			OutputPath: output,
+			Flatten:    true,
+			Arity:      1,
+		})
+	})
+
+	Context("given: unique names", func() {
+		It("🧪 should: drop sub-path and retain name", func() {
+			a := item(filepath.Join("2023", "summer"), "a.jpg")
+			finder.Register(a)
+			finder.Register(item("2024", "b.jpg"))
+
+			folder, file := result(a)
+			Expect(folder).To(Equal(filepath.Join(output, "ADHOC")))
+			Expect(file).To(Equal("a.jpg"))
+		})
+	})
+
+	Context("given: same name in different folders", func() {
+		It("🧪 should: disambiguate deterministically", func() {
+			summer := item("2023", "a.jpg")
+			winter := item("2024", "a.jpeg")
+			finder.Register(summer)
+			finder.Register(winter)
+
+			_, first := result(summer)
+			_, second := result(winter)
+			_, again := result(summer)
+
+			Expect(first).To(MatchRegexp(`^a\.[0-9a-f]{8}\.jpg$`))
+			Expect(second).To(MatchRegexp(`^a\.[0-9a-f]{8}\.jpg$`))
+			Expect(first).NotTo(Equal(second))
+			Expect(again).To(Equal(first))
+		})
+	})
+})
@@ -0,0 +1,139 @@
+package filing
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sync"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// NewLedger creates a Ledger that records results as produced by the
+// version of pixa.
+func NewLedger(vfs storage.VirtualFS, version string) common.Ledger {
+	return &Ledger{
+		vfs:     vfs,
+		version: version,
+		xattrs:  vfs.Backend() == nativeBackend,
+	}
+}
+
+// Ledger records each result in an extended attribute of the result
+// itself. Where extended attributes are not supported (by the platform,
+// the file system or a virtual file system), the record is kept in a manifest, which is a
+// sidecar file in the folder of the result, keyed by file name. The
+// manifest is shared by all the results in the folder, so access to it
+// is guarded.
+type Ledger struct {
+	vfs     storage.VirtualFS
+	version string
+	xattrs  bool
+	mx      sync.Mutex
+}
+
+// nativeBackend is the only backend whose paths can be passed on to the
+// extended attribute system calls.
+const nativeBackend = storage.VirtualBackend("native")
+
+// manifest is the content of the sidecar, keyed by file name
+type manifest map[string]*common.ProcessedRecord
+
+// Mark records that the file at path is a result of the profile, along
+// with the hash of its content.
+func (l *Ledger) Mark(path, profile string) error {
+	hash, err := ContentHash(l.vfs, path)
+	if err != nil {
+		return err
+	}
+
+	record := &common.ProcessedRecord{
+		Profile: profile,
+		Version: l.version,
+		Hash:    hash,
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if l.xattrs && setXattr(path, common.Definitions.Filing.Xattr, data) == nil {
+		return nil
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	entries, err := l.read(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+
+	entries[filepath.Base(path)] = record
+
+	return l.write(filepath.Dir(path), entries)
+}
+
+// Processed determines whether the file at path has been marked and its
+// content is unchanged since, ie the file is a result that should not be
+// processed again.
+func (l *Ledger) Processed(path string) bool {
+	record := l.record(path)
+	if record == nil {
+		return false
+	}
+
+	hash, err := ContentHash(l.vfs, path)
+
+	return err == nil && hash == record.Hash
+}
+
+func (l *Ledger) record(path string) *common.ProcessedRecord {
+	if l.xattrs {
+		if data, err := getXattr(path, common.Definitions.Filing.Xattr); err == nil {
+			record := &common.ProcessedRecord{}
+
+			if json.Unmarshal(data, record) == nil {
+				return record
+			}
+		}
+	}
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	entries, err := l.read(filepath.Dir(path))
+	if err != nil {
+		return nil
+	}
+
+	return entries[filepath.Base(path)]
+}
+
+func (l *Ledger) read(folder string) (manifest, error) {
+	entries := manifest{}
+	path := filepath.Join(folder, common.Definitions.Filing.Manifest)
+
+	if !l.vfs.FileExists(path) {
+		return entries, nil
+	}
+
+	data, err := l.vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, json.Unmarshal(data, &entries)
+}
+
+func (l *Ledger) write(folder string, entries manifest) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return l.vfs.WriteFile(
+		filepath.Join(folder, common.Definitions.Filing.Manifest), data, beezledub,
+	)
+}
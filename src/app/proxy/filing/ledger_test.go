@@ -0,0 +1,107 @@
+package filing_test
+
+import (
+	"fmt"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+)
+
+type ledgerTE struct {
+	given    string
+	should   string
+	mark     bool
+	modified bool
+	manifest bool
+	expected bool
+}
+
+// manifestFS is a file system whose paths can't be given extended
+// attributes, which forces the ledger to use the manifest.
+type manifestFS struct {
+	storage.VirtualFS
+}
+
+func (fs *manifestFS) Backend() storage.VirtualBackend {
+	return "manifest"
+}
+
+var _ = Describe("Ledger", func() {
+	var (
+		vfs    storage.VirtualFS
+		result string
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		result = filepath.Join(GinkgoT().TempDir(), "a.jpg")
+		Expect(vfs.WriteFile(result, []byte("shrunk"), common.Permissions.Beezledub)).To(Succeed())
+	})
+
+	DescribeTable("Processed",
+		func(entry *ledgerTE) {
+			if entry.manifest {
+				vfs = &manifestFS{VirtualFS: vfs}
+			}
+
+			ledger := filing.NewLedger(vfs, "v0.1.0")
+
+			if entry.mark {
+				Expect(ledger.Mark(result, "blur")).To(Succeed())
+			}
+
+			if entry.modified {
+				Expect(vfs.WriteFile(result, []byte("edited"), common.Permissions.Beezledub)).To(Succeed())
+			}
+
+			Expect(ledger.Processed(result)).To(Equal(entry.expected))
+			Expect(vfs.FileExists(
+				filepath.Join(filepath.Dir(result), common.Definitions.Filing.Manifest),
+			)).To(Equal(entry.manifest && entry.mark))
+		},
+		func(entry *ledgerTE) string {
+			return fmt.Sprintf("🧪 ===> given: '%v', should: '%v'",
+				entry.given, entry.should,
+			)
+		},
+
+		Entry(nil, &ledgerTE{
+			given:    "result not marked",
+			should:   "not be processed",
+			expected: false,
+		}),
+		Entry(nil, &ledgerTE{
+			given:    "marked result",
+			should:   "be processed",
+			mark:     true,
+			expected: true,
+		}),
+		Entry(nil, &ledgerTE{
+			given:    "marked result modified since",
+			should:   "not be processed",
+			mark:     true,
+			modified: true,
+			expected: false,
+		}),
+		Entry(nil, &ledgerTE{
+			given:    "result marked in manifest",
+			should:   "be processed",
+			mark:     true,
+			manifest: true,
+			expected: true,
+		}),
+		Entry(nil, &ledgerTE{
+			given:    "result marked in manifest modified since",
+			should:   "not be processed",
+			mark:     true,
+			manifest: true,
+			modified: true,
+			expected: false,
+		}),
+	)
+})
@@ -2,8 +2,10 @@ package filing
 
 import (
 	"fmt"
+	"maps"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/snivilised/extendio/xfs/nav"
@@ -12,11 +14,13 @@ import (
 
 type NewFinderInfo struct {
 	Advanced   common.AdvancedConfig
+	Filing     common.FilingConfig
 	Schemes    common.SchemesConfig
 	Scheme     string
 	Profile    string
 	OutputPath string
 	TrashPath  string
+	Flatten    bool
 	Observer   common.PathFinder
 	Arity      uint
 }
@@ -33,6 +37,8 @@ func NewFinder(
 			Transformers: strings.Split(extensions.Transforms(), ","),
 			Remap:        extensions.Map(),
 		},
+		Templates: pfTemplates.override(info.Filing),
+		Date:      time.Now().Format(time.DateOnly),
 	}
 
 	finder.init(info)
@@ -69,6 +75,7 @@ var (
 
 /*
 📚 FIELD DICTIONARY:
+(the layouts below can be overridden by the filing.templates config)
 - ADHOC: (static): tag that indicates no profile or scheme is active
 - TRANSFER-DESTINATION: the path where the input file is moved to
 - ITEM-FULL-NAME: the original item.Name, which includes the original extension
@@ -83,7 +90,15 @@ might run a sample shrink on ~/library/pics. This would create ~/library/pics/pr
 The user may re-run on the same path, but this second run would see that old
 trash path and attempt to process those files, by accident. To fix this,
 ~/library/pics/profile/TRASH would be replaced by ~/library/pics/<DEJA-VU>/profile/TRASH
-where <DEJA-VU> would be skipped by he navigation process.
+where <DEJA-VU> would be skipped by he navigation process. The label is
+advanced.labels.deja-vu, which defaults to the trash label. Since the label
+may be configured differently on the next run, every folder that pixa creates
+to hold its outputs also contains a DEJA-VU marker file (see MakeFolder),
+which the navigation hooks look for, in addition to the labels.
+- DATE: the date of the run (yyyy-mm-dd)
+- EXT: the extension of the input, without the dot
+- PROFILE: the current profile (may be empty)
+- SCHEME: the current scheme (may be empty)
 */
 
 func init() {
@@ -110,36 +125,50 @@ func (tc pfTemplatesCollection) expand(segments ...string) string {
 	return filepath.Join(segments...)
 }
 
+// override returns a copy of the templates, with those defined by the
+// config taking the place of the built in ones. The templates of the
+// config have already been validated when the config was loaded.
+func (tc pfTemplatesCollection) override(filing common.FilingConfig) pfTemplatesCollection {
+	result := maps.Clone(tc)
+
+	if filing == nil {
+		return result
+	}
+
+	templates := filing.Templates()
+
+	for path, template := range map[pfPath]string{
+		pfPathInputTransferFolder:      templates.Transfer(),
+		pfPathTxInputDestinationFolder: templates.Transparent(),
+		pfPathResultFolder:             templates.Result(),
+	} {
+		if template != "" {
+			result[path] = strings.Split(template, "/")
+		}
+	}
+
+	return result
+}
+
 // evaluate returns a string representing a file system path from a
 // template string containing place-holders and field values.
 //
-// Make sure that the keys of the values passed in match the segments.
-// If they differ, then the result will contain unresolved segments (ie,
-// 1 or more segments that are not evaluated and still contain the
-// template placeholder.)
+// The segments dictate the order of the path and each segment may contain
+// any number of fields, along with literal text. Any field without a
+// value is not evaluated and still contains the template placeholder.
 func (tc pfTemplatesCollection) evaluate(
 	values pfFieldValues,
 	segments ...string,
 ) string {
-	// There is a very subtle but important point to note about the evaluate
-	// method, in particular the parameters being passed in. It might seem
-	// to the reader that the segments being passed in are redundant, because
-	// they could be derived from the keys of the values map. However, map
-	// entries do not have a guaranteed iteration order. Only arrays are
-	// guaranteed to remain in the same order in which they were created. This
-	// is the purpose of the segments parameter; it dictates the order in which
-	// the segments of a path are evaluated. We can't even use the OrderedKeys
-	// map, because entries are sorted lexically, which is not what we want.
-	//
-	const (
-		quantity = 1
-	)
-
 	sourceTemplate := filepath.Join(segments...)
-	result := lo.Reduce(segments, func(acc, field string, _ int) string {
-		return strings.Replace(acc, field, values[field], quantity)
-	},
-		sourceTemplate,
+	result := common.TemplateFieldPattern.ReplaceAllStringFunc(sourceTemplate,
+		func(field string) string {
+			if value, found := values[field]; found {
+				return value
+			}
+
+			return field
+		},
 	)
 
 	return filepath.Clean(result)
@@ -165,6 +194,9 @@ type PathFinder struct {
 	Ext              *ExtensionTransformation
 	transparentInput bool
 	Stats            *common.StaticInfo
+	Templates        pfTemplatesCollection
+	Date             string     // the date of the run, for the DATE field
+	Flattener        *Flattener // only present when flattening
 }
 
 func (f *PathFinder) init(info *NewFinderInfo) {
@@ -175,6 +207,10 @@ func (f *PathFinder) init(info *NewFinderInfo) {
 	// with the --output flag, then the input is no longer transparent, as the user has
 	// to go to the output location to see the result.
 	f.transparentInput = info.OutputPath == "" && info.Arity == 1
+
+	if info.Flatten {
+		f.Flattener = NewFlattener()
+	}
 }
 
 func (f *PathFinder) JournalFullPath(item *nav.TraverseItem) string {
@@ -185,6 +221,14 @@ func (f *PathFinder) JournalFullPath(item *nav.TraverseItem) string {
 	return file
 }
 
+// Register makes the finder aware of the item ahead of the principal
+// traversal, so that the names of flattened results can be disambiguated.
+func (f *PathFinder) Register(item *nav.TraverseItem) {
+	if f.Flattener != nil {
+		f.Flattener.Register(item.Extension.SubPath, f.mutateExtension(item.Extension.Name))
+	}
+}
+
 func (f *PathFinder) Statics() *common.StaticInfo {
 	return f.Stats
 }
@@ -212,7 +256,7 @@ func (f *PathFinder) Transfer(info *common.PathInfo) (folder, file string) {
 			return ""
 		}
 
-		segments := pfTemplates[pfPathInputTransferFolder]
+		segments := f.Templates[pfPathInputTransferFolder]
 		to := lo.TernaryF(f.Trash != "",
 			func() string {
 				return f.Trash
@@ -223,12 +267,12 @@ func (f *PathFinder) Transfer(info *common.PathInfo) (folder, file string) {
 		)
 		// eventually, we need to use the cuddle option here
 		//
-		return pfTemplates.evaluate(pfFieldValues{
+		return f.Templates.evaluate(f.fields(info, pfFieldValues{
 			"${{TRANSFER-DESTINATION}}": to,
 			"${{ITEM-SUB-PATH}}":        info.Item.Extension.SubPath,
-			"${{DEJA-VU}}":              f.Stats.TrashTag(),
+			"${{DEJA-VU}}":              f.Stats.DejaVuTag(),
 			"${{SUPPLEMENT}}":           f.FolderSupplement(info.Profile),
-		}, segments...)
+		}), segments...)
 	}()
 
 	file = func() string {
@@ -264,6 +308,13 @@ func (f *PathFinder) Transfer(info *common.PathInfo) (folder, file string) {
 	return folder, file
 }
 
+// Quarantine creates the path that the input of a failed item is moved
+// to, under the error-policy quarantine. The input keeps its name, but is
+// moved into the failed folder alongside it.
+func (f *PathFinder) Quarantine(info *common.PathInfo) (folder, file string) {
+	return filepath.Join(info.Origin, f.Stats.FailedTag()), info.Item.Extension.Name
+}
+
 func (f *PathFinder) mutateExtension(file string) string {
 	extension := filepath.Ext(file)
 	withoutDot := extension[1:]
@@ -293,20 +344,23 @@ func (f *PathFinder) mutateExtension(file string) string {
 // Result creates a path for each result so should be called by the
 // execution step
 func (f *PathFinder) Result(info *common.PathInfo) (folder, file string) {
+	alongside := f.transparentInput || info.IsCuddling || info.IsSampling
+	subPath := lo.Ternary(f.Flattener != nil, "", info.Item.Extension.SubPath)
+
 	folder = func() string {
-		return lo.TernaryF(f.transparentInput || info.IsCuddling || info.IsSampling,
+		return lo.TernaryF(alongside,
 			func() string {
 				// The result file has to be in the same folder
 				// as the input
 				//
-				segments := pfTemplates[pfPathTxInputDestinationFolder]
+				segments := f.Templates[pfPathTxInputDestinationFolder]
 
-				return pfTemplates.evaluate(pfFieldValues{
+				return f.Templates.evaluate(f.fields(info, pfFieldValues{
 					"${{OUTPUT-ROOT}}": info.Origin,
-				}, segments...)
+				}), segments...)
 			},
 			func() string {
-				segments := pfTemplates[pfPathResultFolder]
+				segments := f.Templates[pfPathResultFolder]
 				to := lo.TernaryF(f.Output != "",
 					func() string {
 						return f.Output
@@ -321,11 +375,11 @@ func (f *PathFinder) Result(info *common.PathInfo) (folder, file string) {
 				// the result should reflect the supplementary path.
 				//
 
-				return pfTemplates.evaluate(pfFieldValues{
+				return f.Templates.evaluate(f.fields(info, pfFieldValues{
 					"${{OUTPUT-ROOT}}":   to,
-					"${{ITEM-SUB-PATH}}": info.Item.Extension.SubPath,
+					"${{ITEM-SUB-PATH}}": subPath,
 					"${{SUPPLEMENT}}":    f.FolderSupplement(info.Profile),
-				}, segments...)
+				}), segments...)
 			},
 		)
 	}()
@@ -342,7 +396,11 @@ func (f *PathFinder) Result(info *common.PathInfo) (folder, file string) {
 				withSampling = f.Stats.Sample
 			}
 
-			supp := lo.TernaryF(info.IsSampling,
+			// When sampling a scheme, all the profiles write their results
+			// alongside the input, so the profile must be part of the
+			// supplement to prevent them from overwriting each other.
+			//
+			supp := lo.TernaryF(info.IsSampling && f.Sch == "",
 				func() string {
 					return f.SampleFileSupplement(withSampling)
 				},
@@ -359,7 +417,26 @@ func (f *PathFinder) Result(info *common.PathInfo) (folder, file string) {
 		return info.Item.Extension.Name
 	}()
 
-	return folder, f.mutateExtension(file)
+	file = f.mutateExtension(file)
+
+	if f.Flattener != nil && !alongside {
+		// all the results are in the same folder, so inputs of the same
+		// name from different folders have to be told apart
+		//
+		file = f.Flattener.Name(info.Item.Extension.SubPath, file)
+	}
+
+	return folder, file
+}
+
+// fields adds the values of the fields that are available to every template
+func (f *PathFinder) fields(info *common.PathInfo, values pfFieldValues) pfFieldValues {
+	values[common.TemplateFields.Date] = f.Date
+	values[common.TemplateFields.Ext] = strings.TrimPrefix(filepath.Ext(info.Item.Extension.Name), ".")
+	values[common.TemplateFields.Profile] = info.Profile
+	values[common.TemplateFields.Scheme] = f.Sch
+
+	return values
 }
 
 func (f *PathFinder) FolderSupplement(profile string) string {
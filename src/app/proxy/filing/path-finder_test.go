@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
 	. "github.com/onsi/gomega"    //nolint:revive // foo
@@ -32,8 +33,10 @@ type pfTE struct {
 	output         string
 	trash          string
 	cuddle         bool
+	sample         bool
 	dry            bool
 	actionTransfer bool
+	filing         common.FilingConfig
 	assert         asserter
 }
 
@@ -68,7 +71,7 @@ var _ = Describe("PathFinder", Ordered, func() {
 		}
 
 		advanced = &cfg.MsAdvancedConfig{
-			Abort: true,
+			Policy: "abort",
 			LabelsCFG: cfg.MsLabelsConfig{
 				Adhoc:      "ADHOC",
 				Journal:    "journal",
@@ -76,6 +79,7 @@ var _ = Describe("PathFinder", Ordered, func() {
 				Trash:      "TRASH",
 				Fake:       ".FAKE",
 				Supplement: "SUPP",
+				Sample:     "SAMPLE",
 			},
 			ExtensionsCFG: cfg.MsExtensionsConfig{
 				FileSuffixes:  "jpg,jpeg,png",
@@ -105,6 +109,7 @@ var _ = Describe("PathFinder", Ordered, func() {
 			)
 			finder := filing.NewFinder(&filing.NewFinderInfo{
 				Advanced:   advanced,
+				Filing:     entry.filing,
 				Schemes:    schemes,
 				Scheme:     entry.scheme,
 				OutputPath: entry.output,
@@ -124,6 +129,7 @@ var _ = Describe("PathFinder", Ordered, func() {
 				Profile:    entry.profile,
 				Scheme:     entry.scheme,
 				IsCuddling: entry.cuddle,
+				IsSampling: entry.sample,
 				Output:     entry.output,
 				Trash:      entry.trash,
 			}
@@ -579,5 +585,117 @@ var _ = Describe("PathFinder", Ordered, func() {
 				Expect(file).To(Equal(pi.Item.Extension.Name), because(entry.reasons.file))
 			},
 		}),
+
+		//
+		// === TEMPLATES
+		//
+		Entry(nil, &pfTE{
+			given:  "🎁 RESULT: not-transparent/profile/output/result template",
+			should: "lay out result folder according to template",
+			reasons: reasons{
+				folder: "result template overrides the built in layout",
+				file:   "filename only needs to match input filename because the folder is supplemented",
+			},
+			profile: "blur",
+			output:  filepath.Join("foo", "sessions", "scan01", "results"),
+			filing: &cfg.MsFilingConfig{
+				TemplatesCFG: cfg.MsTemplatesConfig{
+					ResultTempl: "${{OUTPUT-ROOT}}/web/${{EXT}}/${{PROFILE}}-${{DATE}}",
+				},
+			},
+			assert: func(folder, file string, pi *common.PathInfo, _ *common.StaticInfo, entry *pfTE) {
+				Expect(folder).To(Equal(filepath.Join(
+					entry.output, "web", "jpg", "blur-"+time.Now().Format(time.DateOnly),
+				)), because(entry.reasons.folder))
+				Expect(file).To(Equal(pi.Item.Extension.Name), because(entry.reasons.file))
+			},
+		}),
+
+		Entry(nil, &pfTE{
+			given:  "🌀 TRANSFER: transparent/profile/not-cuddled/transfer template",
+			should: "redirect input according to template // filename not modified",
+			reasons: reasons{
+				folder: "transfer template overrides the built in layout",
+				file:   "file should be moved out of the way and not cuddled",
+			},
+			profile:        "blur",
+			actionTransfer: true,
+			filing: &cfg.MsFilingConfig{
+				TemplatesCFG: cfg.MsTemplatesConfig{
+					TransferTempl: "${{TRANSFER-DESTINATION}}/${{DEJA-VU}}/${{SCHEME}}/${{PROFILE}}",
+				},
+			},
+			assert: func(folder, file string, pi *common.PathInfo, _ *common.StaticInfo, entry *pfTE) {
+				Expect(folder).To(Equal(filepath.Join(pi.Origin, "$TRASH$", "blur")), because(entry.reasons.folder))
+				Expect(file).To(Equal(pi.Item.Extension.Name), because(entry.reasons.file))
+			},
+		}),
+
+		//
+		// === SAMPLING
+		//
+
+		Entry(nil, &pfTE{
+			given:  "🎁 RESULT: sample/profile",
+			should: "not modify folder // file decorated with sample",
+			reasons: reasons{
+				folder: "sample result is alongside the input",
+				file:   "sample result must not replace the input",
+			},
+			profile: "blur",
+			sample:  true,
+			assert: func(folder, file string, pi *common.PathInfo, statics *common.StaticInfo, entry *pfTE) {
+				Expect(folder).To(Equal(pi.Origin), because(entry.reasons.folder))
+				Expect(file).To(Equal(filing.SupplementFilename(
+					pi.Item.Extension.Name, "$SAMPLE$", statics,
+				)), because(entry.reasons.file, file))
+			},
+		}),
+
+		Entry(nil, &pfTE{
+			given:  "🎁 RESULT: sample/scheme",
+			should: "not modify folder // file decorated with sample, scheme and profile",
+			reasons: reasons{
+				folder: "sample result is alongside the input",
+				file:   "sample results of the profiles of a scheme must not overwrite each other",
+			},
+			scheme:  "blur-sf",
+			profile: "sf",
+			sample:  true,
+			assert: func(folder, file string, pi *common.PathInfo, statics *common.StaticInfo, entry *pfTE) {
+				Expect(folder).To(Equal(pi.Origin), because(entry.reasons.folder))
+				Expect(file).To(Equal(filing.SupplementFilename(
+					pi.Item.Extension.Name, "$SAMPLE$.blur-sf.sf", statics,
+				)), because(entry.reasons.file, file))
+			},
+		}),
 	)
+
+	When("deja-vu label is configured", func() {
+		It("🧪 should: transfer input to the folder of that label", func() {
+			labelled := *advanced
+			labelled.LabelsCFG.DejaVu = "DEJA-VU"
+
+			finder := filing.NewFinder(&filing.NewFinderInfo{
+				Advanced: &labelled,
+				Schemes:  schemes,
+				Arity:    1,
+			})
+			origin := filepath.Join("foo", "sessions", "scan01")
+			pi := &common.PathInfo{
+				Item: &nav.TraverseItem{
+					Path: origin,
+					Extension: nav.ExtendedItem{
+						Name: "01_Backyard-Worlds-Planet-9_s01.jpg",
+					},
+				},
+				Origin:  origin,
+				Profile: "blur",
+			}
+
+			folder, file := finder.Transfer(pi)
+			Expect(folder).To(Equal(filepath.Join(origin, "$DEJA-VU$", "blur")))
+			Expect(file).To(Equal(pi.Item.Extension.Name))
+		})
+	})
 })
@@ -0,0 +1,26 @@
+//go:build linux
+
+package filing
+
+import (
+	"syscall"
+)
+
+func setXattr(path, name string, data []byte) error {
+	return syscall.Setxattr(path, name, data, 0)
+}
+
+func getXattr(path, name string) ([]byte, error) {
+	size, err := syscall.Getxattr(path, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, size)
+
+	if size, err = syscall.Getxattr(path, name, data); err != nil {
+		return nil, err
+	}
+
+	return data[:size], nil
+}
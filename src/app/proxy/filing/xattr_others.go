@@ -0,0 +1,18 @@
+//go:build !linux
+
+package filing
+
+import (
+	"errors"
+)
+
+// errXattrUnsupported causes the ledger to fall back to the manifest
+var errXattrUnsupported = errors.New("extended attributes not supported")
+
+func setXattr(_, _ string, _ []byte) error {
+	return errXattrUnsupported
+}
+
+func getXattr(_, _ string) ([]byte, error) {
+	return nil, errXattrUnsupported
+}
@@ -4,12 +4,17 @@ import (
 	"fmt"
 
 	"github.com/samber/lo"
+	"github.com/snivilised/cobrass/src/assistant"
+	"github.com/snivilised/cobrass/src/store"
 	"github.com/snivilised/extendio/xfs/nav"
 	"github.com/snivilised/pixa/src/app/proxy/common"
 )
 
+// filterSetup derives the filters from the poly family of the command
+// being run, along with the folder filters of the root
 type filterSetup struct {
-	inputs *common.ShrinkCommandInputs
+	root    *common.RootCommandInputs
+	polyFam *assistant.ParamSet[store.PolyFilterParameterSet]
 }
 
 func (s *filterSetup) getDefs(statics *common.StaticInfo) *nav.FilterDefinitions {
@@ -22,11 +27,11 @@ func (s *filterSetup) getDefs(statics *common.StaticInfo) *nav.FilterDefinitions
 		defs          *nav.FilterDefinitions
 		folderDefined = true
 		pattern       string
-		suffixes      = s.inputs.Root.Configs.Advanced.Extensions().Suffixes()
+		suffixes      = s.root.Configs.Advanced.Extensions().Suffixes()
 	)
 
 	switch {
-	case s.inputs.PolyFam.Native.Files != "":
+	case s.polyFam.Native.Files != "":
 		exclusion := statics.JournalFilterGlob()
 		// 📚 The pattern defined uses an exclusion, but this is no longer
 		// necessary here in pixa, because we provide a custom ReadDirectory
@@ -36,7 +41,7 @@ func (s *filterSetup) getDefs(statics *common.StaticInfo) *nav.FilterDefinitions
 		// so this exclusion filtering will remain in place.
 		//
 		pattern = fmt.Sprintf("%v/%v|%v",
-			s.inputs.PolyFam.Native.Files,
+			s.polyFam.Native.Files,
 			exclusion,
 			suffixes,
 		)
@@ -47,8 +52,8 @@ func (s *filterSetup) getDefs(statics *common.StaticInfo) *nav.FilterDefinitions
 			Scope:       nav.ScopeFileEn,
 		}
 
-	case s.inputs.PolyFam.Native.FilesRexEx != "":
-		pattern = statics.JournalFilterRegex(s.inputs.PolyFam.Native.FilesRexEx, suffixes)
+	case s.polyFam.Native.FilesRexEx != "":
+		pattern = statics.JournalFilterRegex(s.polyFam.Native.FilesRexEx, suffixes)
 
 		file = &nav.FilterDef{
 			Type:        nav.FilterTypeRegexEn,
@@ -69,8 +74,8 @@ func (s *filterSetup) getDefs(statics *common.StaticInfo) *nav.FilterDefinitions
 	}
 
 	switch {
-	case s.inputs.Root.FoldersFam.Native.FoldersGlob != "":
-		pattern = s.inputs.Root.FoldersFam.Native.FoldersRexEx
+	case s.root.FoldersFam.Native.FoldersGlob != "":
+		pattern = s.root.FoldersFam.Native.FoldersRexEx
 		folder = &nav.FilterDef{
 			Type:        nav.FilterTypeGlobEn,
 			Description: fmt.Sprintf("--folders-gb(Z): '%v'", pattern),
@@ -78,8 +83,8 @@ func (s *filterSetup) getDefs(statics *common.StaticInfo) *nav.FilterDefinitions
 			Scope:       nav.ScopeFolderEn | nav.ScopeLeafEn,
 		}
 
-	case s.inputs.Root.FoldersFam.Native.FoldersRexEx != "":
-		pattern = s.inputs.Root.FoldersFam.Native.FoldersRexEx
+	case s.root.FoldersFam.Native.FoldersRexEx != "":
+		pattern = s.root.FoldersFam.Native.FoldersRexEx
 		folder = &nav.FilterDef{
 			Type:        nav.FilterTypeRegexEn,
 			Description: fmt.Sprintf("--folders-rx(Y): '%v'", pattern),
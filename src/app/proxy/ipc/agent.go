@@ -42,7 +42,7 @@ func New(
 		}
 
 		if !agent.IsInstalled() {
-			err = ErrUseDummyExecutor
+			err = ErrExecutableNotFound
 		}
 
 	case common.Definitions.ThirdParty.Dummy:
@@ -64,9 +64,12 @@ func Pacify(
 	fm common.FileManager,
 	dummy bool,
 ) common.ExecutionAgent {
+	// a pacified agent must never run the program, eg in a dry run, only
+	// describe what would have been run.
+	//
 	base := baseAgent{
 		knownBy: knownBy,
-		program: &ProgramExecutor{
+		program: &DummyExecutor{
 			Name: advanced.Executable().Symbol(),
 		},
 	}
@@ -18,13 +18,23 @@ func (a *fakeAgent) IsInstalled() bool {
 func (a *fakeAgent) Invoke(thirdPartyCL clif.ThirdPartyCommandLine,
 	source, destination string,
 ) error {
-	before := []string{source}
-
-	// >>> if err := a.fm.Create(destination, false); err != nil {
-	// 	return err
-	// }
+	// the result is a placeholder, so that whatever happens to it subsequently
+	// can be observed, eg in a simulation. The file manager ignores this in
+	// a dry run.
+	//
+	if err := a.fm.Create(destination, true); err != nil {
+		return err
+	}
 
 	return a.program.Execute(
-		clif.Expand(before, thirdPartyCL, destination)...,
+		a.CommandLine(thirdPartyCL, source, destination)[1:]...,
 	)
 }
+
+func (a *fakeAgent) CommandLine(thirdPartyCL clif.ThirdPartyCommandLine,
+	source, destination string,
+) []string {
+	before := []string{a.program.ProgName(), source}
+
+	return clif.Expand(before, thirdPartyCL, destination)
+}
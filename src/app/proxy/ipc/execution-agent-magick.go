@@ -15,9 +15,15 @@ func (a *magickAgent) IsInstalled() bool {
 }
 
 func (a *magickAgent) Invoke(thirdPartyCL clif.ThirdPartyCommandLine, source, destination string) error {
-	before := []string{source}
-
 	return a.program.Execute(
-		clif.Expand(before, thirdPartyCL, destination)...,
+		a.CommandLine(thirdPartyCL, source, destination)[1:]...,
 	)
 }
+
+func (a *magickAgent) CommandLine(thirdPartyCL clif.ThirdPartyCommandLine,
+	source, destination string,
+) []string {
+	before := []string{a.program.ProgName(), source}
+
+	return clif.Expand(before, thirdPartyCL, destination)
+}
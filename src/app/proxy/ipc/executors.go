@@ -1,7 +1,16 @@
 package ipc
 
 import (
+	"errors"
 	"os/exec"
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+const (
+	// maxCapturedOutput is the number of bytes retained of each of the
+	// stdout and stderr streams of the program.
+	maxCapturedOutput = 4 * 1024
 )
 
 type ProgramExecutor struct {
@@ -16,19 +25,66 @@ func (e *ProgramExecutor) Look() (string, error) {
 	return exec.LookPath(e.Name)
 }
 
+// Execute runs the program, capturing its output so that if it fails, the
+// returned *common.ExecutionError can describe why.
 func (e *ProgramExecutor) Execute(args ...string) error {
-	_ = args
-	// todo: ✨ executing
-	//
+	var (
+		stdout = &boundedBuffer{limit: maxCapturedOutput}
+		stderr = &boundedBuffer{limit: maxCapturedOutput}
+	)
+
 	// #nosec G204 // prog(e.Name) is pre-vetted
 	cmd := exec.Command(e.Name, args...)
-	err := cmd.Start()
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err == nil {
+		return nil
+	}
+
+	exitCode := -1
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		exitCode = exitErr.ExitCode()
+	}
 
-	if err != nil {
-		return err
+	return &common.ExecutionError{
+		Program:   e.Name,
+		Args:      args,
+		ExitCode:  exitCode,
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+		Err:       err,
 	}
+}
+
+// boundedBuffer retains the first limit bytes written to it and discards
+// the rest, so that a program producing copious output can't exhaust
+// memory. Writes never fail, otherwise the program would be disrupted.
+type boundedBuffer struct {
+	limit     int
+	content   []byte
+	truncated bool
+}
+
+func (b *boundedBuffer) Write(p []byte) (int, error) {
+	remaining := b.limit - len(b.content)
+
+	if len(p) > remaining {
+		b.truncated = true
+		b.content = append(b.content, p[:max(remaining, 0)]...)
+	} else {
+		b.content = append(b.content, p...)
+	}
+
+	return len(p), nil
+}
 
-	return cmd.Wait()
+func (b *boundedBuffer) String() string {
+	return string(b.content)
 }
 
 type DummyExecutor struct {
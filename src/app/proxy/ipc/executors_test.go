@@ -0,0 +1,69 @@
+package ipc_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/ipc"
+)
+
+var _ = Describe("ProgramExecutor", func() {
+	var (
+		executor *ipc.ProgramExecutor
+	)
+
+	BeforeEach(func() {
+		executor = &ipc.ProgramExecutor{
+			Name: "sh",
+		}
+	})
+
+	Context("given: program succeeds", func() {
+		It("🧪 should: not return error", func() {
+			Expect(executor.Execute("-c", "echo done")).To(Succeed())
+		})
+	})
+
+	Context("given: program fails", func() {
+		It("🧪 should: return execution error with captured output", func() {
+			err := executor.Execute("-c", "echo working; echo 'bad image' >&2; exit 3")
+
+			var execErr *common.ExecutionError
+			Expect(errors.As(err, &execErr)).To(BeTrue())
+			Expect(execErr.Program).To(Equal("sh"))
+			Expect(execErr.Args).To(HaveLen(2))
+			Expect(execErr.ExitCode).To(Equal(3))
+			Expect(execErr.Stdout).To(Equal("working\n"))
+			Expect(execErr.Stderr).To(Equal("bad image\n"))
+			Expect(execErr.Truncated).To(BeFalse())
+			Expect(err.Error()).To(Equal("'sh' exited with code 3: bad image"))
+		})
+	})
+
+	Context("given: program produces copious output", func() {
+		It("🧪 should: truncate output", func() {
+			err := executor.Execute("-c", "yes error | head -c 100000 >&2; exit 1")
+
+			var execErr *common.ExecutionError
+			Expect(errors.As(err, &execErr)).To(BeTrue())
+			Expect(execErr.Truncated).To(BeTrue())
+			Expect(len(execErr.Stderr)).To(BeNumerically("<", 100000))
+			Expect(strings.HasPrefix(execErr.Stderr, "error\n")).To(BeTrue())
+		})
+	})
+
+	Context("given: program not found", func() {
+		It("🧪 should: return execution error without exit code", func() {
+			executor.Name = "pixa-missing-program"
+			err := executor.Execute()
+
+			var execErr *common.ExecutionError
+			Expect(errors.As(err, &execErr)).To(BeTrue())
+			Expect(execErr.ExitCode).To(Equal(-1))
+		})
+	})
+})
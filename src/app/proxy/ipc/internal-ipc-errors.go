@@ -6,5 +6,5 @@ import "errors"
 // internally and are of no significance to the user directly, which
 // means they also don't need to be i18n error messages.
 
-var ErrUseDummyExecutor = errors.New("using dummy executor")
+var ErrExecutableNotFound = errors.New("executable not found")
 var ErrUnsupportedExecutor = errors.New("unsupported executor")
@@ -0,0 +1,13 @@
+package ipc_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+)
+
+func TestIpc(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ipc Suite")
+}
@@ -3,10 +3,13 @@ package orc
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/samber/lo"
 	"github.com/snivilised/cobrass/src/clif"
 	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/quality"
+	"github.com/snivilised/pixa/src/locale"
 )
 
 // controllerStep uses the agent to combine parameters together so that the program
@@ -20,6 +23,7 @@ type controllerStep struct {
 	sourcePath   string
 	outputPath   string
 	journalPath  string
+	targetSize   int64
 }
 
 // Run
@@ -29,7 +33,21 @@ func (s *controllerStep) Run(pi *common.PathInfo) error {
 	folder, file := finder.Result(pi)
 	destination := filepath.Join(folder, file)
 
-	err := lo.TernaryF(s.session.FileManager.FileExists(destination),
+	if s.session.Planner != nil {
+		s.session.Planner.Plan(&common.PlannedOperation{
+			Source:     pi.Item.Path,
+			Input:      pi.RunStep.Source,
+			Scheme:     pi.Scheme,
+			Profile:    s.profile,
+			Command:    s.session.Agent.CommandLine(s.thirdPartyCL, pi.RunStep.Source, destination),
+			Result:     destination,
+			TargetSize: s.targetSize,
+		})
+	}
+
+	var hash string // of the input, by which the result is cached
+
+	err := lo.TernaryF(s.occupied(pi, destination),
 		func() error {
 			return fmt.Errorf("skipping file: '%v'", destination)
 		},
@@ -39,25 +57,201 @@ func (s *controllerStep) Run(pi *common.PathInfo) error {
 			//
 			destination = filepath.Join(folder, file)
 
-			if s.session.FileManager.FileExists(destination) {
+			if s.occupied(pi, destination) {
 				// todo: rename the sample
 				//
 				return fmt.Errorf("skipping existing sample file: '%v'", destination)
 			}
 
+			if err := s.session.FileManager.EnsureFolder(folder); err != nil {
+				return err
+			}
+
+			var (
+				reused bool
+				err    error
+			)
+
+			if reused, hash, err = s.reuse(pi, destination); reused || err != nil {
+				return err
+			}
+
+			if s.targetSize > 0 && !s.session.Inputs.Root.PreviewFam.Native.DryRun {
+				return s.search(pi, destination)
+			}
+
 			return s.session.Agent.Invoke(
 				s.thirdPartyCL, pi.RunStep.Source, destination,
 			)
 		},
 	)
 
-	s.session.Interaction.Tick(&common.ProgressMsg{
+	var (
+		metrics   *common.QualityMetrics
+		rejection error
+	)
+
+	if missed, ok := err.(locale.TargetSizeNotReachedErrorBehaviourQuery); ok && missed.TargetSizeNotReached() {
+		// not reaching the target is an outcome in its own right, rather
+		// than a failure of the step, in the same way as a rejection.
+		//
+		rejection, err = err, s.session.FileManager.Reject(pi, destination)
+	} else if err == nil {
+		metrics, rejection, err = s.assess(pi, destination)
+	}
+
+	if err == nil && rejection == nil {
+		err = s.mark(destination)
+	}
+
+	if err == nil && rejection == nil && hash != "" {
+		err = s.cache(hash, destination)
+	}
+
+	msg := &common.ProgressMsg{
 		Source:      pi.RunStep.Source,
 		Destination: destination,
 		Scheme:      pi.Scheme,
 		Profile:     s.profile,
-		Err:         err,
-	})
+		Quality:     metrics,
+		Err:         lo.Ternary(err != nil, err, rejection),
+	}
+
+	s.session.Interaction.Tick(msg)
+
+	if s.session.Recorder != nil {
+		s.session.Recorder.Record(msg)
+	}
 
 	return err
 }
+
+// reuse puts the result of an identical input, produced by an earlier
+// step with the same command, in place of the destination, so that the
+// agent does not have to be invoked. The hash of the input is returned,
+// so that the result can be cached, when there is a cache.
+func (s *controllerStep) reuse(pi *common.PathInfo, destination string) (reused bool, hash string, err error) {
+	native := s.session.Inputs.ParamSet.Native
+
+	if s.session.Cache == nil || s.targetSize > 0 {
+		// the result of a target size search depends on the attempts
+		// made, so it is not cached
+		//
+		return false, "", nil
+	}
+
+	// even when forced, the input is looked up, so that its new result
+	// replaces the cached one
+	//
+	result, hash, err := s.session.Cache.Lookup(pi.RunStep.Source, s.profile, s.command())
+	if err != nil || result == "" || result == destination || native.Force {
+		return false, hash, err
+	}
+
+	if err = s.session.FileManager.Reuse(result, destination, native.Link); err != nil {
+		return false, hash, err
+	}
+
+	return true, hash, nil
+}
+
+// command is the signature of the command that produces the result,
+// without its input and result, which qualifies a cached result.
+func (s *controllerStep) command() string {
+	return strings.Join(lo.Compact(s.session.Agent.CommandLine(s.thirdPartyCL, "", "")), " ")
+}
+
+// mark records the result in the ledger, so that it is not processed
+// again by an incremental run.
+func (s *controllerStep) mark(destination string) error {
+	if s.session.Ledger == nil || s.session.Inputs.Root.PreviewFam.Native.DryRun ||
+		!s.session.FileManager.FileExists(destination) {
+		return nil
+	}
+
+	return s.session.Ledger.Mark(destination, s.profile)
+}
+
+// cache records the result against the hash of its input, so that it can
+// be reused for an identical input. There is nothing to record when the
+// program did not produce a result (eg, a dummy run).
+func (s *controllerStep) cache(hash, destination string) error {
+	if !s.session.FileManager.FileExists(destination) {
+		return nil
+	}
+
+	return s.session.Cache.Record(hash, s.profile, s.command(), destination)
+}
+
+// occupied determines whether there is already a file at the destination.
+// In a dry run, the input is not moved out of the way during setup, so
+// the input itself does not count when the result is to take its place.
+func (s *controllerStep) occupied(pi *common.PathInfo, destination string) bool {
+	if s.session.Inputs.Root.PreviewFam.Native.DryRun &&
+		destination == pi.Item.Path && pi.RunStep.Source != pi.Item.Path {
+		return false
+	}
+
+	return s.session.FileManager.FileExists(destination)
+}
+
+// assess measures the quality of the result, when required to do so, and
+// rejects the result if it falls below the minimum permitted. A rejection
+// is not a failure of the step; the original is retained and the step
+// is deemed to have completed.
+func (s *controllerStep) assess(pi *common.PathInfo,
+	destination string,
+) (metrics *common.QualityMetrics, rejection, err error) {
+	if !s.measuring(pi) || !s.session.FileManager.FileExists(destination) {
+		return nil, nil, nil
+	}
+
+	source, err := s.session.FileManager.ReadFile(pi.RunStep.Source)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := s.session.FileManager.ReadFile(destination)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if metrics, err = quality.Measure(source, result); err != nil {
+		// the program may have produced something that is not an image
+		// that can be decoded natively (eg, a dummy run), which means
+		// there is nothing to assess.
+		//
+		return nil, nil, nil
+	}
+
+	threshold := s.session.Inputs.ParamSet.Native.MinSSIM
+
+	if threshold > 0 && metrics.SSIM < threshold {
+		if err = s.session.FileManager.Reject(pi, destination); err != nil {
+			return metrics, nil, err
+		}
+
+		rejection = locale.NewQualityBelowThresholdError(destination, metrics.SSIM, threshold)
+	}
+
+	return metrics, rejection, nil
+}
+
+func (s *controllerStep) measuring(pi *common.PathInfo) bool {
+	if s.session.Inputs.Root.PreviewFam.Native.DryRun {
+		return false
+	}
+
+	if s.session.Inputs.ParamSet.Native.MinSSIM > 0 {
+		return true
+	}
+
+	switch s.session.Inputs.Root.Configs.Advanced.Quality().Metrics() {
+	case common.Definitions.Quality.Always:
+		return true
+	case common.Definitions.Quality.Sample:
+		return pi.IsSampling
+	}
+
+	return false
+}
@@ -1,11 +1,16 @@
 package orc
 
 import (
+	"errors"
+	"path/filepath"
+	"strings"
+
 	"github.com/snivilised/cobrass"
 	"github.com/snivilised/cobrass/src/clif"
 	"github.com/snivilised/extendio/collections"
 	"github.com/snivilised/extendio/xfs/nav"
 	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
 )
 
 type Controller struct {
@@ -63,13 +68,14 @@ func (c *Controller) sequence(pi *common.PathInfo, fn sequenceFunc) common.Seque
 
 func (c *Controller) profile(pi *common.PathInfo) common.Sequence {
 	changed := c.session.Inputs.ParamSet.Native.ThirdPartySet.LongChangedCL
-	combined := c.compose(pi.Profile, changed)
+	combined := c.compose(pi, pi.Profile, changed)
 	step := &controllerStep{
 		session:      c.session,
 		thirdPartyCL: combined,
-		sourcePath:   pi.Item.Path,
 		profile:      pi.Profile,
+		sourcePath:   pi.Item.Path,
 		outputPath:   c.session.Inputs.ParamSet.Native.OutputPath,
+		targetSize:   c.targetSize(pi, pi.Profile),
 	}
 
 	return common.Sequence{step}
@@ -81,13 +87,14 @@ func (c *Controller) scheme(pi *common.PathInfo) common.Sequence {
 	sequence := make(common.Sequence, 0, len(schemeCfg.Profiles()))
 
 	for _, current := range schemeCfg.Profiles() {
-		combined := c.compose(current, changed)
+		combined := c.compose(pi, current, changed)
 		step := &controllerStep{
 			session:      c.session,
 			thirdPartyCL: combined,
-			sourcePath:   pi.Item.Path,
 			profile:      current,
+			sourcePath:   pi.Item.Path,
 			outputPath:   c.session.Inputs.ParamSet.Native.OutputPath,
+			targetSize:   c.targetSize(pi, current),
 		}
 
 		sequence = append(sequence, step)
@@ -103,28 +110,70 @@ func (c *Controller) adhoc(pi *common.PathInfo) common.Sequence {
 		thirdPartyCL: changed,
 		sourcePath:   pi.Item.Path,
 		outputPath:   c.session.Inputs.ParamSet.Native.OutputPath,
+		targetSize:   c.targetSize(pi, ""),
 	}
 
 	return common.Sequence{step}
 }
 
 func (c *Controller) compose(
+	pi *common.PathInfo,
 	profileName string,
 	secondary clif.ThirdPartyCommandLine,
 ) clif.ThirdPartyCommandLine {
-	primary, _ := c.configs.Profiles.Profile(profileName) // profile already validated
+	primary, _ := c.configs.Profiles.ProfileFor( // profile already validated
+		profileName, c.extensions(pi)...,
+	)
 
 	return cobrass.Evaluate(
-		primary,
+		common.WithoutProfileKeys(primary),
 		c.session.Inputs.ParamSet.Native.ThirdPartySet.KnownBy,
 		secondary,
 	)
 }
 
+// targetSize returns the byte budget for results created with the profile;
+// the --target-size flag takes precedence over the profile. Both have
+// already been validated, so a size that can't be parsed denotes that
+// there is no budget.
+func (c *Controller) targetSize(pi *common.PathInfo, profileName string) int64 {
+	size := c.session.Inputs.ParamSet.Native.TargetSize
+
+	if size == "" && profileName != "" {
+		if profile, found := c.configs.Profiles.ProfileFor(
+			profileName, c.extensions(pi)...,
+		); found {
+			size = profile[common.Definitions.ProfileKeys.TargetSize]
+		}
+	}
+
+	if size == "" {
+		return 0
+	}
+
+	bytes, _ := common.ParseByteSize(size)
+
+	return bytes
+}
+
+// extensions returns the candidate extensions used to select the
+// per-extension section of a profile for the item; its own extension
+// followed by the extension it is mapped to, if any.
+func (c *Controller) extensions(pi *common.PathInfo) []string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(pi.Item.Extension.Name), "."))
+	candidates := []string{ext}
+
+	if mapped, found := c.configs.Advanced.Extensions().Map()[ext]; found && mapped != ext {
+		candidates = append(candidates, mapped)
+	}
+
+	return candidates
+}
+
 func (c *Controller) Run(item *nav.TraverseItem, sequence common.Sequence) error {
 	var (
-		zero common.Step
-		err  error
+		zero     common.Step
+		failures []error
 	)
 
 	iterator := collections.ForwardRunIt[common.Step, error](sequence, zero)
@@ -136,11 +185,11 @@ func (c *Controller) Run(item *nav.TraverseItem, sequence common.Sequence) error
 		return step.Run(&c.private.Pi)
 	}
 	while := func(_ common.Step, e error) bool {
-		if err == nil {
-			err = e
+		if e != nil {
+			failures = append(failures, e)
 		}
 
-		return e == nil || !c.configs.Advanced.AbortOnError()
+		return e == nil || c.policy() != common.ErrorPolicyAbortEn
 	}
 
 	c.private.Pi = common.PathInfo{
@@ -154,22 +203,67 @@ func (c *Controller) Run(item *nav.TraverseItem, sequence common.Sequence) error
 		Trash:      c.session.Inputs.ParamSet.Native.TrashPath,
 	}
 
-	// TODO: need to decide a proper policy for cleaning up
-	// in the presence of an error. Do we allow the journal
-	// file to remain in place? What happens if there is a timeout?
-	// There are a few more things to decide about error handling.
-	// Perhaps we have an error policy including one that implements
-	// a retry.
-	//
+	var err error
+
 	if c.private.Pi.RunStep.Source, err = c.session.FileManager.Setup(
 		&c.private.Pi,
 	); err != nil {
+		// no step has run, so the failure has to be recorded here
+		//
+		if c.session.Recorder != nil {
+			c.session.Recorder.Record(&common.ProgressMsg{
+				Source:  item.Path,
+				Scheme:  c.private.Pi.Scheme,
+				Profile: c.private.Pi.Profile,
+				Err:     err,
+			})
+		}
+
+		failures = append(failures, err)
+	} else {
+		iterator.RunAll(each, while)
+	}
+
+	// the journal file is removed regardless of the outcome, so that
+	// the item is not mistaken for one that was interrupted.
+	//
+	tidyErr := c.session.FileManager.Tidy(&c.private.Pi)
+
+	if len(failures) > 0 {
+		return c.fail(errors.Join(failures...))
+	}
+
+	return tidyErr
+}
+
+func (c *Controller) policy() common.ErrorPolicyEnum {
+	return c.session.Inputs.ParamSet.Native.ErrorPolicyEn.Value()
+}
+
+// fail applies the error policy to an item that failed. Returning an
+// error stops the traversal, which happens when the policy is to abort,
+// or the number of failures has reached the maximum permitted.
+func (c *Controller) fail(err error) error {
+	switch c.policy() { //nolint:exhaustive // continue has nothing to do
+	case common.ErrorPolicyAbortEn:
 		return err
+
+	case common.ErrorPolicyQuarantineEn:
+		if qErr := c.session.FileManager.Quarantine(&c.private.Pi, err); qErr != nil {
+			return qErr
+		}
 	}
 
-	iterator.RunAll(each, while)
+	count := uint(1)
+	if c.session.Failures != nil {
+		count = uint(c.session.Failures.Add(1))
+	}
+
+	if limit := c.session.Inputs.ParamSet.Native.MaxFailures; limit > 0 && count >= limit {
+		return locale.NewMaxFailuresReachedError(limit)
+	}
 
-	return c.session.FileManager.Tidy(&c.private.Pi)
+	return nil
 }
 
 func (c *Controller) Reset() {}
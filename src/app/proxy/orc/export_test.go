@@ -0,0 +1,22 @@
+package orc
+
+import (
+	"github.com/snivilised/cobrass/src/clif"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// Search runs the target size search of a step, for the profile
+func Search(session *common.SessionControllerInfo, thirdPartyCL clif.ThirdPartyCommandLine,
+	targetSize int64, pi *common.PathInfo, destination string,
+) error {
+	step := &controllerStep{
+		session:      session,
+		thirdPartyCL: thirdPartyCL,
+		profile:      pi.Profile,
+		targetSize:   targetSize,
+	}
+
+	return step.search(pi, destination)
+}
+
+var WithQuality = withQuality
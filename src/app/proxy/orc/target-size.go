@@ -0,0 +1,129 @@
+package orc
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/snivilised/cobrass/src/clif"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+const (
+	minQuality = 1
+	maxQuality = 100
+	half       = 2
+)
+
+// search binary searches the quality of the result, until it fits within
+// the target size. Each attempt is created in a temporary file alongside
+// the destination and only replaces the destination when it fits. Since
+// a larger quality is only ever tried after a fit, the destination always
+// contains the highest quality result found so far that fits. The search
+// stops when a result is within the tolerance of the target, when the
+// range of qualities is exhausted or when the maximum number of attempts
+// has been made, or immediately when the executor produces no output.
+func (s *controllerStep) search(pi *common.PathInfo, destination string) error {
+	fm := s.session.FileManager
+	config := s.session.Inputs.Root.Configs.Advanced.TargetSize()
+	attempt := fm.Finder().Statics().AttemptLocation(destination)
+	threshold := int64(float64(s.targetSize) * (1 - config.Tolerance()))
+
+	var (
+		accepted bool
+		smallest int64 = -1
+	)
+
+	defer fm.Remove(attempt) //nolint:errcheck // best effort, not fatal
+
+	low, high := minQuality, maxQuality
+
+	for no := uint(1); no <= config.MaxAttempts() && low <= high; no++ {
+		quality := (low + high) / half
+
+		if err := s.session.Agent.Invoke(
+			withQuality(s.thirdPartyCL, quality), pi.RunStep.Source, attempt,
+		); err != nil {
+			return err
+		}
+
+		// an executor that produces no output (eg, the dummy program),
+		// leaves nothing to measure, so there is nothing to search for.
+		//
+		if !fm.FileExists(attempt) {
+			return nil
+		}
+
+		size, err := fm.FileSize(attempt)
+		if err != nil {
+			return err
+		}
+
+		s.session.Interaction.Tick(&common.ProgressMsg{
+			Source:      pi.RunStep.Source,
+			Destination: destination,
+			Scheme:      pi.Scheme,
+			Profile:     s.profile,
+			Attempt: &common.TargetAttempt{
+				No:      no,
+				Quality: quality,
+				Size:    size,
+				Target:  s.targetSize,
+			},
+		})
+
+		if smallest < 0 || size < smallest {
+			smallest = size
+		}
+
+		if size > s.targetSize {
+			high = quality - 1
+
+			continue
+		}
+
+		if err := fm.Move(attempt, destination); err != nil {
+			return err
+		}
+
+		accepted = true
+
+		if size >= threshold {
+			break
+		}
+
+		low = quality + 1
+	}
+
+	if !accepted {
+		return locale.NewTargetSizeNotReachedError(destination, s.targetSize, smallest)
+	}
+
+	return nil
+}
+
+// withQuality returns a copy of the command line with any existing quality
+// replaced by the one specified.
+func withQuality(cl clif.ThirdPartyCommandLine, quality int) clif.ThirdPartyCommandLine {
+	const (
+		flagAndValue = 2
+	)
+
+	flags := []string{"--quality", "-quality", "-q"}
+	result := make(clif.ThirdPartyCommandLine, 0, len(cl)+flagAndValue)
+
+	for i := 0; i < len(cl); i++ {
+		if slices.Contains(flags, cl[i]) {
+			if i+1 < len(cl) && !strings.HasPrefix(cl[i+1], "-") {
+				i++
+			}
+
+			continue
+		}
+
+		result = append(result, cl[i])
+	}
+
+	return append(result, "--quality", strconv.Itoa(quality))
+}
@@ -0,0 +1,260 @@
+package orc_test
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+	"github.com/samber/lo"
+
+	"github.com/snivilised/cobrass/src/clif"
+	"github.com/snivilised/extendio/xfs/nav"
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/cfg"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/filing"
+	"github.com/snivilised/pixa/src/app/proxy/orc"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+// sizingAgent creates results whose size depends on the quality they
+// were created with, so that the search can be steered. Without a size,
+// no result is created, as with the dummy executor.
+type sizingAgent struct {
+	vfs       storage.VirtualFS
+	size      func(quality int) int
+	qualities []int
+}
+
+func (a *sizingAgent) IsInstalled() bool {
+	return true
+}
+
+func (a *sizingAgent) Invoke(thirdPartyCL clif.ThirdPartyCommandLine,
+	_, destination string,
+) error {
+	index := slices.Index(thirdPartyCL, "--quality")
+	quality, err := strconv.Atoi(thirdPartyCL[index+1])
+
+	if err != nil {
+		return err
+	}
+
+	a.qualities = append(a.qualities, quality)
+
+	if a.size == nil {
+		return nil
+	}
+
+	return a.vfs.WriteFile(destination,
+		bytes.Repeat([]byte{0}, a.size(quality)), common.Permissions.Beezledub,
+	)
+}
+
+func (a *sizingAgent) CommandLine(thirdPartyCL clif.ThirdPartyCommandLine,
+	source, destination string,
+) []string {
+	return append(append([]string{"sizer", source}, thirdPartyCL...), destination)
+}
+
+type tickingInteraction struct {
+	common.UserInteraction
+	attempts []*common.TargetAttempt
+}
+
+func (i *tickingInteraction) Tick(progress *common.ProgressMsg) {
+	i.attempts = append(i.attempts, progress.Attempt)
+}
+
+type searchTE struct {
+	given       string
+	should      string
+	size        func(quality int) int
+	target      int64
+	maxAttempts uint
+	qualities   []int
+	result      int64
+	smallest    int64
+	noOutput    bool
+}
+
+var _ = Describe("TargetSize", func() {
+	var (
+		vfs         storage.VirtualFS
+		destination string
+		pi          *common.PathInfo
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		origin := GinkgoT().TempDir()
+		destination = filepath.Join(origin, "result.jpg")
+		pi = &common.PathInfo{
+			Item: &nav.TraverseItem{
+				Path: filepath.Join(origin, "input.jpg"),
+			},
+			Profile: "blur",
+			RunStep: common.RunStepInfo{
+				Source: filepath.Join(origin, "input.jpg"),
+			},
+		}
+	})
+
+	DescribeTable("search",
+		func(entry *searchTE) {
+			advanced := &cfg.MsAdvancedConfig{
+				LabelsCFG: cfg.MsLabelsConfig{
+					Journal: "journal",
+				},
+				TargetSizeCFG: cfg.MsTargetSizeConfig{
+					Within:   0.05,
+					Attempts: entry.maxAttempts,
+				},
+			}
+			finder := filing.NewFinder(&filing.NewFinderInfo{
+				Advanced: advanced,
+				Schemes:  &cfg.MsSchemesConfig{},
+				Arity:    1,
+			})
+			agent := &sizingAgent{
+				vfs:  vfs,
+				size: entry.size,
+			}
+			interaction := &tickingInteraction{}
+			session := &common.SessionControllerInfo{
+				Agent: agent,
+				Inputs: &common.ShrinkCommandInputs{
+					Root: &common.RootCommandInputs{
+						Configs: &common.Configs{
+							Advanced: advanced,
+						},
+					},
+				},
+				FileManager: filing.NewManager(vfs, finder, false),
+				Interaction: interaction,
+			}
+
+			err := orc.Search(session, clif.ThirdPartyCommandLine{"--strip", "--quality", "85"},
+				entry.target, pi, destination,
+			)
+
+			Expect(agent.qualities).To(Equal(entry.qualities))
+			Expect(interaction.attempts).To(HaveLen(lo.Ternary(entry.noOutput, 0, len(entry.qualities))))
+			Expect(vfs.FileExists(finder.Statics().AttemptLocation(destination))).To(BeFalse(),
+				"attempt should be removed",
+			)
+
+			if entry.smallest > 0 {
+				Expect(err).To(BeAssignableToTypeOf(locale.TargetSizeNotReachedError{}))
+
+				data := err.(locale.TargetSizeNotReachedError).Data.(locale.TargetSizeNotReachedTemplData) //nolint:errcheck,errorlint // asserted above
+				Expect(data.Smallest).To(Equal(entry.smallest))
+				Expect(data.Target).To(Equal(entry.target))
+				Expect(vfs.FileExists(destination)).To(BeFalse())
+
+				return
+			}
+
+			Expect(err).To(Succeed())
+
+			if entry.noOutput {
+				Expect(vfs.FileExists(destination)).To(BeFalse())
+
+				return
+			}
+
+			info, err := vfs.Stat(destination)
+			Expect(err).To(Succeed())
+			Expect(info.Size()).To(Equal(entry.result))
+		},
+		func(entry *searchTE) string {
+			return fmt.Sprintf("🧪 ===> given: '%v', should: '%v'", entry.given, entry.should)
+		},
+
+		Entry(nil, &searchTE{
+			given:       "first attempt within tolerance",
+			should:      "stop after first attempt",
+			size:        func(quality int) int { return quality * 100 },
+			target:      5000,
+			maxAttempts: 10,
+			qualities:   []int{50},
+			result:      5000,
+		}),
+		Entry(nil, &searchTE{
+			given:       "first attempt too large",
+			should:      "converge until within tolerance",
+			size:        func(quality int) int { return quality * 100 },
+			target:      4321,
+			maxAttempts: 10,
+			qualities:   []int{50, 25, 37, 43},
+			result:      4300,
+		}),
+		Entry(nil, &searchTE{
+			given:       "no quality fits",
+			should:      "fail with the smallest size",
+			size:        func(quality int) int { return 10000 + quality*100 },
+			target:      5000,
+			maxAttempts: 10,
+			qualities:   []int{50, 25, 12, 6, 3, 1},
+			smallest:    10100,
+		}),
+		Entry(nil, &searchTE{
+			given:       "attempts capped after fit",
+			should:      "keep the best fit so far",
+			size:        func(quality int) int { return quality * 100 },
+			target:      4321,
+			maxAttempts: 2,
+			qualities:   []int{50, 25},
+			result:      2500,
+		}),
+		Entry(nil, &searchTE{
+			given:       "attempts capped before fit",
+			should:      "fail with the smallest size",
+			size:        func(quality int) int { return quality * 100 },
+			target:      4321,
+			maxAttempts: 1,
+			qualities:   []int{50},
+			smallest:    5000,
+		}),
+		Entry(nil, &searchTE{
+			given:       "executor produces no output",
+			should:      "stop without error",
+			target:      4321,
+			maxAttempts: 10,
+			qualities:   []int{50},
+			noOutput:    true,
+		}),
+	)
+
+	DescribeTable("withQuality",
+		func(cl, expected clif.ThirdPartyCommandLine) {
+			Expect(orc.WithQuality(cl, 42)).To(Equal(expected))
+		},
+		func(cl, _ clif.ThirdPartyCommandLine) string {
+			return fmt.Sprintf("🧪 ===> given: '%v', should: replace quality", cl)
+		},
+
+		Entry(nil, clif.ThirdPartyCommandLine{"--strip"},
+			clif.ThirdPartyCommandLine{"--strip", "--quality", "42"},
+		),
+		Entry(nil, clif.ThirdPartyCommandLine{"--quality", "85", "--strip"},
+			clif.ThirdPartyCommandLine{"--strip", "--quality", "42"},
+		),
+		Entry(nil, clif.ThirdPartyCommandLine{"-quality", "85", "--strip"},
+			clif.ThirdPartyCommandLine{"--strip", "--quality", "42"},
+		),
+		Entry(nil, clif.ThirdPartyCommandLine{"--strip", "-q", "85"},
+			clif.ThirdPartyCommandLine{"--strip", "--quality", "42"},
+		),
+		Entry(nil, clif.ThirdPartyCommandLine{"-q", "--strip"},
+			clif.ThirdPartyCommandLine{"--strip", "--quality", "42"},
+		),
+		Entry(nil, clif.ThirdPartyCommandLine{},
+			clif.ThirdPartyCommandLine{"--quality", "42"},
+		),
+	)
+})
@@ -118,6 +118,10 @@ func (o *testPathFinderObserver) Transfer(info *common.PathInfo) (folder, file s
 	return folder, file
 }
 
+func (o *testPathFinderObserver) Quarantine(info *common.PathInfo) (folder, file string) {
+	return o.target.Quarantine(info)
+}
+
 func (o *testPathFinderObserver) Result(info *common.PathInfo) (folder, file string) {
 	folder, file = o.target.Result(info) // info.Item is wrong
 	statics := o.Statics()
@@ -157,6 +161,10 @@ func (o *testPathFinderObserver) Statics() *common.StaticInfo {
 	return o.target.Statics()
 }
 
+func (o *testPathFinderObserver) Register(item *nav.TraverseItem) {
+	o.target.Register(item)
+}
+
 func (o *testPathFinderObserver) Scheme() string {
 	return o.target.Scheme()
 }
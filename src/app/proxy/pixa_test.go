@@ -113,6 +113,32 @@ func createSamples(entry *pixaTE,
 	}
 }
 
+// createUnmarkedTrash creates the trash folder of a run by a version of
+// pixa that pre-dates the DEJA-VU marker, holding inputs that should
+// not be processed again.
+func createUnmarkedTrash(entry *pixaTE, origin string, vfs storage.VirtualFS) {
+	destination := filepath.Join(origin, entry.supplement)
+
+	if err := vfs.MkdirAll(destination, common.Permissions.Write); err != nil {
+		Fail(fmt.Sprintf("could not create trash path: '%v'", destination))
+	}
+
+	for _, input := range entry.inputs {
+		create := filepath.Join(destination, trashed(input))
+		if f, e := vfs.Create(create); e != nil {
+			Fail(fmt.Sprintf("could not create trashed file: '%v'", create))
+		} else {
+			f.Close()
+		}
+	}
+}
+
+func trashed(input string) string {
+	ext := filepath.Ext(input)
+
+	return strings.TrimSuffix(input, ext) + ".trashed" + ext
+}
+
 type pixaTE struct {
 	given              string
 	should             string
@@ -366,6 +392,31 @@ var _ = Describe("pixa", Ordered, func() {
 				},
 			},
 		}),
+		Entry(nil, &pixaTE{
+			given:    "regex/transparent/adhoc/unmarked trash (re-run)",
+			should:   "not process inputs trashed by an earlier version",
+			relative: BackyardWorldsPlanet9Scan01,
+			reasons: reasons{
+				folder: "trash folder pre-dates the deja-vu marker",
+				file:   "trashed input should not be processed again",
+			},
+			arrange: func(entry *pixaTE, origin string) {
+				createUnmarkedTrash(entry, origin, vfs)
+			},
+			args: []string{
+				"--files-rx", "Backyard-Worlds",
+				"--gaussian-blur", "0.51",
+				"--interlace", "line",
+			},
+			intermediate: "nasa/exo/Backyard Worlds - Planet 9/sessions/scan-01",
+			supplement:   filepath.Join("$TRASH$", "ADHOC"),
+			inputs:       helpers.BackyardWorldsPlanet9Scan01First6,
+			asserters: asserters{
+				result: func(entry *pixaTE, input, _ string, _ *pathAssertion, _ storage.VirtualFS) {
+					Expect(input).NotTo(ContainSubstring(".trashed"), because(entry.reasons.file))
+				},
+			},
+		}),
 		//
 		// TRANSPARENT --trash SPECIFIED
 		//
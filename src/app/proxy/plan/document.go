@@ -0,0 +1,195 @@
+package plan
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+const (
+	// DocumentVersion is the version of the layout of the plan file
+	DocumentVersion = 1
+)
+
+type (
+	// Document is the plan of a shrink run, written by shrink --plan so that
+	// it can be audited and then executed as is by apply.
+	Document struct {
+		Version   int       `json:"version"`
+		Created   time.Time `json:"created"`
+		Directory string    `json:"directory"`
+		Items     []*Item   `json:"items"`
+	}
+
+	// Item is the planned processing of a single input. Trash is where the
+	// input is moved to before any step is run, if it has to be moved out
+	// of the way of the results.
+	Item struct {
+		Source      string      `json:"source"`
+		Fingerprint Fingerprint `json:"fingerprint"`
+		Trash       string      `json:"trash,omitempty"`
+		Steps       []*Step     `json:"steps"`
+	}
+
+	// Step is the planned invocation of the third party program for a
+	// profile. TargetSize is recorded for a step that searches for the
+	// quality that fits within it, which can't be applied, since the
+	// command that produces the result is only known once searched.
+	Step struct {
+		Profile    string   `json:"profile,omitempty"`
+		Command    []string `json:"command"`
+		Result     string   `json:"result"`
+		TargetSize int64    `json:"target-size,omitempty"`
+	}
+
+	// Fingerprint identifies the content of an input at the time it was
+	// planned, so that a plan is not applied to an input that has changed.
+	Fingerprint struct {
+		Size    int64     `json:"size"`
+		ModTime time.Time `json:"mod-time"`
+		Hash    string    `json:"hash"`
+	}
+)
+
+// Equal determines whether the fingerprints denote the same content.
+func (f Fingerprint) Equal(other Fingerprint) bool {
+	return f.Size == other.Size && f.ModTime.Equal(other.ModTime) && f.Hash == other.Hash
+}
+
+// TakeFingerprint creates the fingerprint of the file at path.
+func TakeFingerprint(vfs storage.VirtualFS, path string) (Fingerprint, error) {
+	info, err := vfs.Stat(path)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return Fingerprint{}, err
+	}
+
+	sum := sha256.Sum256(content)
+
+	return Fingerprint{
+		Size:    info.Size(),
+		ModTime: info.ModTime().UTC(),
+		Hash:    hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// Document creates the plan document from the planned operations, taking
+// the fingerprint of each input.
+func (p *Planner) Document(vfs storage.VirtualFS, directory string) (*Document, error) {
+	doc := &Document{
+		Version:   DocumentVersion,
+		Created:   time.Now().UTC(),
+		Directory: directory,
+		Items:     []*Item{},
+	}
+
+	var item *Item
+
+	for _, op := range p.Operations() {
+		if item == nil || item.Source != op.Source {
+			fingerprint, err := TakeFingerprint(vfs, op.Source)
+			if err != nil {
+				return nil, err
+			}
+
+			item = &Item{
+				Source:      op.Source,
+				Fingerprint: fingerprint,
+				Steps:       []*Step{},
+			}
+
+			if op.Input != op.Source {
+				item.Trash = op.Input
+			}
+
+			doc.Items = append(doc.Items, item)
+		}
+
+		item.Steps = append(item.Steps, &Step{
+			Profile:    op.Profile,
+			Command:    op.Command,
+			Result:     op.Result,
+			TargetSize: op.TargetSize,
+		})
+	}
+
+	return doc, nil
+}
+
+// Validate ensures that every step of the plan can be applied as is, by
+// the program specified.
+func (d *Document) Validate(program string) error {
+	for _, item := range d.Items {
+		for _, step := range item.Steps {
+			if step.Command[0] != program {
+				return fmt.Errorf(
+					"step for '%v' runs '%v', instead of '%v'", item.Source, step.Command[0], program,
+				)
+			}
+
+			if step.TargetSize > 0 {
+				return fmt.Errorf(
+					"step for '%v' searches for a quality to fit within %v bytes, which can't be applied",
+					item.Source, step.TargetSize,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Write writes the plan document to path.
+func Write(vfs storage.VirtualFS, path string, doc *Document) error {
+	content, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return vfs.WriteFile(path, append(content, '\n'), common.Permissions.Beezledub)
+}
+
+// Read reads the plan document at path, ensuring that it is complete.
+func Read(vfs storage.VirtualFS, path string) (*Document, error) {
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+
+	var doc Document
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if doc.Version != DocumentVersion {
+		return nil, fmt.Errorf("unsupported version: %v (expected: %v)", doc.Version, DocumentVersion)
+	}
+
+	for _, item := range doc.Items {
+		if item.Source == "" {
+			return nil, errors.New("item without source")
+		}
+
+		for _, step := range item.Steps {
+			if len(step.Command) == 0 || step.Result == "" {
+				return nil, fmt.Errorf("incomplete step for '%v'", item.Source)
+			}
+		}
+	}
+
+	return &doc, nil
+}
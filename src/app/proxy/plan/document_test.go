@@ -0,0 +1,116 @@
+package plan_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/plan"
+)
+
+var _ = Describe("Document", func() {
+	var (
+		vfs       storage.VirtualFS
+		directory string
+		source    string
+		planner   *plan.Planner
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		directory = GinkgoT().TempDir()
+		source = filepath.Join(directory, "a.jpg")
+		Expect(vfs.WriteFile(source, []byte("original"), common.Permissions.Beezledub)).To(Succeed())
+
+		planner = plan.NewPlanner()
+		for _, profile := range []string{"blur", "sf"} {
+			planner.Plan(&common.PlannedOperation{
+				Source:  source,
+				Input:   source,
+				Profile: profile,
+				Command: []string{"magick", source, filepath.Join(directory, profile, "a.jpg")},
+				Result:  filepath.Join(directory, profile, "a.jpg"),
+			})
+		}
+	})
+
+	Context("given: planned operations", func() {
+		It("🧪 should: group steps by item and round trip", func() {
+			doc, err := planner.Document(vfs, directory)
+			Expect(err).To(Succeed())
+			Expect(doc.Items).To(HaveLen(1))
+			Expect(doc.Items[0].Steps).To(HaveLen(2))
+			Expect(doc.Items[0].Trash).To(BeEmpty())
+
+			path := filepath.Join(directory, "plan.json")
+			Expect(plan.Write(vfs, path, doc)).To(Succeed())
+
+			read, err := plan.Read(vfs, path)
+			Expect(err).To(Succeed())
+			Expect(read.Items[0].Steps[1].Profile).To(Equal("sf"))
+			Expect(read.Items[0].Fingerprint.Equal(doc.Items[0].Fingerprint)).To(BeTrue())
+		})
+	})
+
+	Context("given: input changed since planning", func() {
+		It("🧪 should: have a different fingerprint", func() {
+			before, err := plan.TakeFingerprint(vfs, source)
+			Expect(err).To(Succeed())
+
+			Expect(vfs.WriteFile(source, []byte("modified"), common.Permissions.Beezledub)).To(Succeed())
+
+			after, err := plan.TakeFingerprint(vfs, source)
+			Expect(err).To(Succeed())
+			Expect(after.Equal(before)).To(BeFalse())
+		})
+	})
+
+	Context("given: plan to validate", func() {
+		It("🧪 should: accept steps of the program", func() {
+			doc, err := planner.Document(vfs, directory)
+			Expect(err).To(Succeed())
+			Expect(doc.Validate("magick")).To(Succeed())
+		})
+
+		It("🧪 should: reject steps of another program", func() {
+			doc, err := planner.Document(vfs, directory)
+			Expect(err).To(Succeed())
+			Expect(doc.Validate("dummy")).NotTo(Succeed())
+		})
+
+		It("🧪 should: reject step searching for target size", func() {
+			planner.Plan(&common.PlannedOperation{
+				Source:     source,
+				Input:      source,
+				Profile:    "web",
+				Command:    []string{"magick", source, filepath.Join(directory, "web", "a.jpg")},
+				Result:     filepath.Join(directory, "web", "a.jpg"),
+				TargetSize: 200 * 1024,
+			})
+
+			doc, err := planner.Document(vfs, directory)
+			Expect(err).To(Succeed())
+			Expect(doc.Items[0].Steps[2].TargetSize).To(Equal(int64(200 * 1024)))
+			Expect(doc.Validate("magick")).To(MatchError(ContainSubstring("204800 bytes")))
+		})
+	})
+
+	DescribeTable("invalid plan",
+		func(content string) {
+			path := filepath.Join(directory, "plan.json")
+			Expect(vfs.WriteFile(path, []byte(content), common.Permissions.Beezledub)).To(Succeed())
+
+			_, err := plan.Read(vfs, path)
+			Expect(err).NotTo(Succeed())
+		},
+		func(content string) string {
+			return "🧪 ===> should: reject " + content
+		},
+		Entry(nil, `{"version": 2, "items": []}`),
+		Entry(nil, `{"version": 1, "items": [], "bogus": true}`),
+		Entry(nil, `{"version": 1, "items": [{"source": "a.jpg", "steps": [{"command": []}]}]}`),
+	)
+})
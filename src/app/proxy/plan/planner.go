@@ -0,0 +1,107 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// NewPlanner creates a Planner.
+func NewPlanner() *Planner {
+	return &Planner{}
+}
+
+// Planner is a common.Planner that retains the operations of a dry run,
+// so that they can be presented once the traversal has completed, rather
+// than interleaved with the progress of the run.
+type Planner struct {
+	mx         sync.Mutex
+	operations []*common.PlannedOperation
+}
+
+// Plan adds the operation to the plan.
+func (p *Planner) Plan(op *common.PlannedOperation) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.operations = append(p.operations, op)
+}
+
+// Operations returns the planned operations ordered by source. The
+// operations of the same source retain the order of their profiles, as
+// they were planned sequentially.
+func (p *Planner) Operations() []*common.PlannedOperation {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	operations := slices.Clone(p.operations)
+	slices.SortStableFunc(operations, func(a, b *common.PlannedOperation) int {
+		return strings.Compare(a.Source, b.Source)
+	})
+
+	return operations
+}
+
+// Render writes the plan in a form that can be audited; each command line
+// is shell quoted, so that it can be copied and run as is.
+func (p *Planner) Render(w io.Writer) error {
+	for _, op := range p.Operations() {
+		header := op.Source
+		if op.Profile != "" {
+			header = fmt.Sprintf("%v [%v]", header, op.Profile)
+		}
+
+		content := fmt.Sprintf("📋 %v\n", header)
+
+		if op.Input != "" && op.Input != op.Source {
+			content += fmt.Sprintf("  🚚   move: %v -> %v\n", Quote(op.Source), Quote(op.Input))
+		}
+
+		content += fmt.Sprintf("  🚀    run: %v\n", QuoteAll(op.Command))
+		content += fmt.Sprintf("  🎯 result: %v\n", Quote(op.Result))
+
+		if _, err := io.WriteString(w, content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// QuoteAll quotes each of the args, joined into a single command line.
+func QuoteAll(args []string) string {
+	quoted := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		quoted = append(quoted, Quote(arg))
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// Quote returns the arg in a form that a POSIX shell interprets literally;
+// an arg that contains no special characters is returned unchanged.
+func Quote(arg string) string {
+	if arg == "" {
+		return "''"
+	}
+
+	if strings.IndexFunc(arg, special) < 0 {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func special(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return false
+	}
+
+	return !strings.ContainsRune("-_./:=,+@%", r)
+}
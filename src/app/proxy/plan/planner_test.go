@@ -0,0 +1,68 @@
+package plan_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/plan"
+)
+
+var _ = Describe("Planner", func() {
+	DescribeTable("Quote",
+		func(arg, expected string) {
+			Expect(plan.Quote(arg)).To(Equal(expected))
+		},
+		func(arg, expected string) string {
+			return "🧪 ===> quoted: " + expected
+		},
+		Entry(nil, "/foo/bar.jpg", "/foo/bar.jpg"),
+		Entry(nil, "--gaussian-blur", "--gaussian-blur"),
+		Entry(nil, "", "''"),
+		Entry(nil, "/foo/$TRASH$/bar.jpg", "'/foo/$TRASH$/bar.jpg'"),
+		Entry(nil, "/foo/it's here.jpg", `'/foo/it'\''s here.jpg'`),
+	)
+
+	Context("given: planned operations", func() {
+		It("🧪 should: render by source in order of profile", func() {
+			planner := plan.NewPlanner()
+			planner.Plan(&common.PlannedOperation{
+				Source:  "/foo/b.jpg",
+				Input:   "/foo/b.jpg",
+				Profile: "blur",
+				Command: []string{"magick", "/foo/b.jpg", "--strip", "/foo/blur/b.jpg"},
+				Result:  "/foo/blur/b.jpg",
+			})
+			planner.Plan(&common.PlannedOperation{
+				Source:  "/foo/a.jpg",
+				Input:   "/foo/$TRASH$/a.jpg",
+				Command: []string{"magick", "/foo/$TRASH$/a.jpg", "/foo/a.jpg"},
+				Result:  "/foo/a.jpg",
+			})
+			planner.Plan(&common.PlannedOperation{
+				Source:  "/foo/b.jpg",
+				Input:   "/foo/b.jpg",
+				Profile: "sf",
+				Command: []string{"magick", "/foo/b.jpg", "/foo/sf/b.jpg"},
+				Result:  "/foo/sf/b.jpg",
+			})
+
+			var builder strings.Builder
+			Expect(planner.Render(&builder)).To(Succeed())
+
+			Expect(builder.String()).To(Equal(`📋 /foo/a.jpg
+  🚚   move: /foo/a.jpg -> '/foo/$TRASH$/a.jpg'
+  🚀    run: magick '/foo/$TRASH$/a.jpg' /foo/a.jpg
+  🎯 result: /foo/a.jpg
+📋 /foo/b.jpg [blur]
+  🚀    run: magick /foo/b.jpg --strip /foo/blur/b.jpg
+  🎯 result: /foo/blur/b.jpg
+📋 /foo/b.jpg [sf]
+  🚀    run: magick /foo/b.jpg /foo/sf/b.jpg
+  🎯 result: /foo/sf/b.jpg
+`))
+		})
+	})
+})
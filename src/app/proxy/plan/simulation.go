@@ -0,0 +1,270 @@
+package plan
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// NewOverlay creates an Overlay of the real file system, held by the
+// in-memory file system.
+func NewOverlay(real, memory storage.VirtualFS) *Overlay {
+	return &Overlay{
+		real: real,
+		Vfs:  memory,
+	}
+}
+
+// Overlay is a copy of a directory tree, held in memory, that a simulated
+// run is free to modify. The copy retains the paths of the original, so
+// the run only needs to be given the file system of the overlay; any
+// location outside of the tree, such as the output or trash, is created in
+// the overlay too. Since nothing is written to the real file system,
+// there is nothing left behind if the process is killed.
+type Overlay struct {
+	real storage.VirtualFS
+	Vfs  storage.VirtualFS
+}
+
+// Mirror copies the structure of the directory into the overlay. The
+// content of the files is not copied; instead each one contains its own
+// path, which distinguishes an input from a result that later takes its
+// place.
+func (o *Overlay) Mirror(directory string) error {
+	if err := o.Vfs.MkdirAll(directory, common.Permissions.Write); err != nil {
+		return err
+	}
+
+	entries, err := o.real.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		path := filepath.Join(directory, entry.Name())
+
+		if entry.IsDir() {
+			if err := o.Mirror(path); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := o.Vfs.WriteFile(path,
+			[]byte(path), common.Permissions.Beezledub,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Snapshot captures the current state of the roots within the overlay,
+// keyed by path. A root that does not exist in the overlay, eg an output
+// that is yet to be created, is empty.
+func (o *Overlay) Snapshot(roots ...string) (Snapshot, error) {
+	snapshot := Snapshot{}
+
+	for _, root := range roots {
+		if !o.Vfs.DirectoryExists(root) {
+			continue
+		}
+
+		if err := o.capture(root, snapshot); err != nil {
+			return snapshot, err
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (o *Overlay) capture(directory string, snapshot Snapshot) error {
+	entries, err := o.Vfs.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if name := entry.Name(); name == common.Definitions.Filing.DejaVu ||
+			name == common.Definitions.Filing.Manifest {
+			continue
+		}
+
+		path := filepath.Join(directory, entry.Name())
+
+		if entry.IsDir() {
+			snapshot[path] = &Node{Dir: true}
+
+			if err := o.capture(path, snapshot); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		content, err := o.Vfs.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		snapshot[path] = &Node{Content: string(content)}
+	}
+
+	return nil
+}
+
+// Node is an entry of a Snapshot
+type Node struct {
+	Dir     bool
+	Content string
+}
+
+// Snapshot is the state of an overlay at a point in time
+type Snapshot map[string]*Node
+
+// ChangeKind denotes how an entry differs between snapshots
+type ChangeKind string
+
+const (
+	// ChangeNone denotes an entry that is unchanged, but is shown so
+	// that changes beneath it can be located
+	ChangeNone ChangeKind = " "
+	// ChangeAdded denotes an entry that did not exist before
+	ChangeAdded ChangeKind = "+"
+	// ChangeRemoved denotes an entry that no longer exists
+	ChangeRemoved ChangeKind = "-"
+	// ChangeReplaced denotes a file that has been replaced by another
+	// at the same path, eg a result that has taken the place of its input
+	ChangeReplaced ChangeKind = "~"
+)
+
+// Change is the difference in an entry between snapshots
+type Change struct {
+	Path string
+	Dir  bool
+	Kind ChangeKind
+}
+
+// Diff compares the snapshots, returning the changes ordered such that
+// the entries of a directory follow the directory.
+func Diff(before, after Snapshot) []Change {
+	changes := []Change{}
+
+	for path, node := range after {
+		previous, found := before[path]
+
+		switch {
+		case !found:
+			changes = append(changes, Change{Path: path, Dir: node.Dir, Kind: ChangeAdded})
+		case previous.Dir != node.Dir || previous.Content != node.Content:
+			changes = append(changes, Change{Path: path, Dir: node.Dir, Kind: ChangeReplaced})
+		}
+	}
+
+	for path, node := range before {
+		if _, found := after[path]; !found {
+			changes = append(changes, Change{Path: path, Dir: node.Dir, Kind: ChangeRemoved})
+		}
+	}
+
+	slices.SortFunc(changes, func(a, b Change) int {
+		return compareTreePaths(a.Path, b.Path)
+	})
+
+	return changes
+}
+
+// compareTreePaths orders paths so that a directory is immediately followed
+// by its entries, which is not the case with a plain string comparison,
+// since the separator does not sort before every other character.
+func compareTreePaths(a, b string) int {
+	return slices.Compare(
+		strings.Split(a, string(filepath.Separator)),
+		strings.Split(b, string(filepath.Separator)),
+	)
+}
+
+// RenderDiff writes the changes as a tree for each of the roots that
+// contain any, so that it's clear where every result, trashed input and
+// supplement would land. The unchanged ancestors of a change are included
+// to show its location.
+func RenderDiff(w io.Writer, roots []string, changes []Change) error {
+	for _, root := range roots {
+		within := located(changes, root)
+
+		if len(within) == 0 {
+			continue
+		}
+
+		content := fmt.Sprintf("🌳 %v\n", root)
+		shown := map[string]bool{}
+
+		for _, change := range within {
+			relative, _ := filepath.Rel(root, change.Path)
+			segments := strings.Split(relative, string(filepath.Separator))
+
+			for depth := range len(segments) - 1 {
+				ancestor := filepath.Join(segments[:depth+1]...)
+
+				if !shown[ancestor] {
+					shown[ancestor] = true
+					content += line(ChangeNone, depth, segments[depth], true)
+				}
+			}
+
+			if !shown[relative] {
+				shown[relative] = true
+				content += line(change.Kind, len(segments)-1, segments[len(segments)-1], change.Dir)
+			}
+		}
+
+		if _, err := io.WriteString(w, content); err != nil {
+			return err
+		}
+	}
+
+	added, removed, replaced := tally(changes)
+	_, err := fmt.Fprintf(w, "🧮 added: %v, removed: %v, replaced: %v\n", added, removed, replaced)
+
+	return err
+}
+
+// located returns the changes located within the root
+func located(changes []Change, root string) []Change {
+	prefix := root + string(filepath.Separator)
+
+	return slices.DeleteFunc(slices.Clone(changes), func(change Change) bool {
+		return !strings.HasPrefix(change.Path, prefix)
+	})
+}
+
+func line(kind ChangeKind, depth int, name string, dir bool) string {
+	if dir {
+		name += string(filepath.Separator)
+	}
+
+	return fmt.Sprintf("  %v %v%v\n", kind, strings.Repeat("  ", depth), name)
+}
+
+func tally(changes []Change) (added, removed, replaced int) {
+	for _, change := range changes {
+		switch change.Kind {
+		case ChangeAdded:
+			added++
+		case ChangeRemoved:
+			removed++
+		case ChangeReplaced:
+			replaced++
+		case ChangeNone:
+		}
+	}
+
+	return added, removed, replaced
+}
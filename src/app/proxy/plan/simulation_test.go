@@ -0,0 +1,101 @@
+package plan_test
+
+import (
+	"bytes"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/plan"
+)
+
+var _ = Describe("Overlay", func() {
+	var (
+		vfs       storage.VirtualFS
+		directory string
+		overlay   *plan.Overlay
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		directory = filepath.Join(GinkgoT().TempDir(), "pics")
+
+		Expect(vfs.MkdirAll(filepath.Join(directory, "sub"), common.Permissions.Write)).To(Succeed())
+		for _, name := range []string{"a.jpg", "sub/b.jpg"} {
+			Expect(vfs.WriteFile(filepath.Join(directory, name),
+				[]byte("original"), common.Permissions.Beezledub),
+			).To(Succeed())
+		}
+
+		overlay = plan.NewOverlay(vfs, storage.UseMemFS())
+		Expect(overlay.Mirror(directory)).To(Succeed())
+	})
+
+	Context("Mirror", func() {
+		It("🧪 should: copy structure without touching the original", func() {
+			Expect(overlay.Vfs.FileExists(filepath.Join(directory, "sub", "b.jpg"))).To(BeTrue())
+
+			content, err := vfs.ReadFile(filepath.Join(directory, "a.jpg"))
+			Expect(err).To(Succeed())
+			Expect(string(content)).To(Equal("original"))
+		})
+	})
+
+	Context("given: changes made to the overlay", func() {
+		It("🧪 should: render the tree diff", func() {
+			before, err := overlay.Snapshot(directory)
+			Expect(err).To(Succeed())
+
+			// the input is trashed and its result takes its place
+			//
+			a := filepath.Join(directory, "a.jpg")
+			trash := filepath.Join(directory, "$TRASH$")
+			Expect(overlay.Vfs.MkdirAll(trash, common.Permissions.Write)).To(Succeed())
+			Expect(overlay.Vfs.Rename(a, filepath.Join(trash, "a.jpg"))).To(Succeed())
+			Expect(overlay.Vfs.WriteFile(a, []byte{}, common.Permissions.Beezledub)).To(Succeed())
+			Expect(overlay.Vfs.Remove(filepath.Join(directory, "sub", "b.jpg"))).To(Succeed())
+
+			after, err := overlay.Snapshot(directory)
+			Expect(err).To(Succeed())
+
+			changes := plan.Diff(before, after)
+			Expect(changes).To(HaveLen(4))
+
+			var buffer bytes.Buffer
+			Expect(plan.RenderDiff(&buffer, []string{directory}, changes)).To(Succeed())
+			Expect(buffer.String()).To(Equal(
+				"🌳 " + directory + "\n" +
+					"  + $TRASH$/\n" +
+					"  +   a.jpg\n" +
+					"  ~ a.jpg\n" +
+					"    sub/\n" +
+					"  -   b.jpg\n" +
+					"🧮 added: 2, removed: 1, replaced: 1\n",
+			))
+
+			Expect(vfs.DirectoryExists(trash)).To(BeFalse(), "real file system should be untouched")
+		})
+	})
+
+	When("nothing changed", func() {
+		It("🧪 should: only render the tally", func() {
+			before, err := overlay.Snapshot(directory)
+			Expect(err).To(Succeed())
+
+			var buffer bytes.Buffer
+			Expect(plan.RenderDiff(&buffer, []string{directory}, plan.Diff(before, before))).To(Succeed())
+			Expect(buffer.String()).To(Equal("🧮 added: 0, removed: 0, replaced: 0\n"))
+		})
+	})
+
+	When("root does not exist in the overlay", func() {
+		It("🧪 should: be empty", func() {
+			snapshot, err := overlay.Snapshot(filepath.Join(directory, "output"))
+			Expect(err).To(Succeed())
+			Expect(snapshot).To(BeEmpty())
+		})
+	})
+})
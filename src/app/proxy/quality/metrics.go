@@ -0,0 +1,170 @@
+package quality
+
+import (
+	"bytes"
+	"image"
+	"math"
+
+	// register the decoders for the formats that can be measured
+	//
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"github.com/pkg/errors"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// Measure compares the result with the source it was created from and
+// returns the structural similarity and peak signal to noise ratio. The
+// result may have different dimensions to the source (eg if created by
+// a profile that resizes), in which case the source is resampled to the
+// dimensions of the result before the comparison is made.
+func Measure(source, result []byte) (*common.QualityMetrics, error) {
+	src, _, err := image.Decode(bytes.NewReader(source))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode source image")
+	}
+
+	res, _, err := image.Decode(bytes.NewReader(result))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode result image")
+	}
+
+	width, height := res.Bounds().Dx(), res.Bounds().Dy()
+
+	if width == 0 || height == 0 {
+		return nil, errors.New("result image is empty")
+	}
+
+	a := planes(src, width, height)
+	b := planes(res, width, height)
+
+	return &common.QualityMetrics{
+		SSIM: ssim(a.luma, b.luma, width, height),
+		PSNR: psnr(a, b),
+	}, nil
+}
+
+// channels holds the samples of an image in 8 bit range, indexed by
+// y*width+x
+type channels struct {
+	r, g, b, luma []float64
+}
+
+// planes extracts the channels of the image resampled (nearest neighbour)
+// to the dimensions specified.
+func planes(img image.Image, width, height int) *channels {
+	const (
+		shift = 8
+		// ITU-R BT.601 luma coefficients
+		kr = 0.299
+		kg = 0.587
+		kb = 0.114
+	)
+
+	bounds := img.Bounds()
+	size := width * height
+	c := &channels{
+		r:    make([]float64, size),
+		g:    make([]float64, size),
+		b:    make([]float64, size),
+		luma: make([]float64, size),
+	}
+
+	for y := 0; y < height; y++ {
+		sy := bounds.Min.Y + y*bounds.Dy()/height
+
+		for x := 0; x < width; x++ {
+			sx := bounds.Min.X + x*bounds.Dx()/width
+			r, g, b, _ := img.At(sx, sy).RGBA()
+			i := y*width + x
+
+			c.r[i] = float64(r >> shift)
+			c.g[i] = float64(g >> shift)
+			c.b[i] = float64(b >> shift)
+			c.luma[i] = kr*c.r[i] + kg*c.g[i] + kb*c.b[i]
+		}
+	}
+
+	return c
+}
+
+// psnr computes the peak signal to noise ratio in decibels across all
+// colour channels. Identical images have an infinite ratio.
+func psnr(a, b *channels) float64 {
+	const (
+		peak = 255.0
+		noOf = 3
+	)
+
+	var sum float64
+
+	for i := range a.r {
+		dr, dg, db := a.r[i]-b.r[i], a.g[i]-b.g[i], a.b[i]-b.b[i]
+		sum += dr*dr + dg*dg + db*db
+	}
+
+	mse := sum / float64(len(a.r)*noOf)
+
+	if mse == 0 {
+		return math.Inf(1)
+	}
+
+	return 10 * math.Log10(peak*peak/mse)
+}
+
+// ssim computes the mean structural similarity index of the luma planes
+// over a grid of windows, using the constants defined in the original
+// paper (Wang et al, 2004).
+func ssim(a, b []float64, width, height int) float64 {
+	const (
+		window = 8
+		stride = 4
+		peak   = 255.0
+		k1     = 0.01
+		k2     = 0.03
+	)
+
+	c1 := (k1 * peak) * (k1 * peak)
+	c2 := (k2 * peak) * (k2 * peak)
+	w, h := min(window, width), min(window, height)
+
+	var (
+		total float64
+		count int
+	)
+
+	for y := 0; y+h <= height; y += stride {
+		for x := 0; x+w <= width; x += stride {
+			var sa, sb, saa, sbb, sab float64
+
+			for j := y; j < y+h; j++ {
+				for i := x; i < x+w; i++ {
+					va, vb := a[j*width+i], b[j*width+i]
+					sa += va
+					sb += vb
+					saa += va * va
+					sbb += vb * vb
+					sab += va * vb
+				}
+			}
+
+			n := float64(w * h)
+			ma, mb := sa/n, sb/n
+			va := saa/n - ma*ma
+			vb := sbb/n - mb*mb
+			cov := sab/n - ma*mb
+
+			total += ((2*ma*mb + c1) * (2*cov + c2)) /
+				((ma*ma + mb*mb + c1) * (va + vb + c2))
+			count++
+		}
+	}
+
+	if count == 0 {
+		return 1
+	}
+
+	return total / float64(count)
+}
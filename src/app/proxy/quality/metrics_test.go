@@ -0,0 +1,98 @@
+package quality_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/pixa/src/app/proxy/quality"
+)
+
+func encode(width, height int, noise func(x, y int) int) []byte {
+	return scaled(width, height, 1, noise)
+}
+
+// scaled creates an image whose pixels are enlarged by the factor specified,
+// so that it has the same content as the unscaled image of the same width.
+func scaled(width, height, factor int, noise func(x, y int) int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width*factor, height*factor))
+
+	for y := 0; y < height*factor; y++ {
+		for x := 0; x < width*factor; x++ {
+			sx, sy := x/factor, y/factor
+			n := noise(sx, sy)
+			img.Set(x, y, color.RGBA{
+				R: uint8(min(255, max(0, sx*4+n))), //nolint:gosec // test data
+				G: uint8(min(255, max(0, sy*4+n))), //nolint:gosec // test data
+				B: 128,
+				A: 255,
+			})
+		}
+	}
+
+	var buf bytes.Buffer
+	Expect(png.Encode(&buf, img)).To(Succeed())
+
+	return buf.Bytes()
+}
+
+func clean(_, _ int) int {
+	return 0
+}
+
+func noisy(x, y int) int {
+	const amplitude = 40
+
+	if (x+y)%2 == 0 {
+		return amplitude
+	}
+
+	return -amplitude
+}
+
+var _ = Describe("Metrics", func() {
+	Context("given: identical images", func() {
+		It("🧪 should: be a perfect match", func() {
+			content := encode(32, 32, clean)
+			metrics, err := quality.Measure(content, content)
+
+			Expect(err).Error().To(BeNil())
+			Expect(metrics.SSIM).To(BeNumerically("~", 1, 0.0001))
+			Expect(math.IsInf(metrics.PSNR, 1)).To(BeTrue())
+			Expect(metrics.String()).To(ContainSubstring("∞"))
+		})
+	})
+
+	Context("given: degraded result", func() {
+		It("🧪 should: reduce similarity", func() {
+			metrics, err := quality.Measure(encode(32, 32, clean), encode(32, 32, noisy))
+
+			Expect(err).Error().To(BeNil())
+			Expect(metrics.SSIM).To(BeNumerically("<", 0.9))
+			Expect(math.IsInf(metrics.PSNR, 1)).To(BeFalse())
+			Expect(metrics.PSNR).To(BeNumerically(">", 0))
+		})
+	})
+
+	Context("given: result with different dimensions", func() {
+		It("🧪 should: measure against resampled source", func() {
+			metrics, err := quality.Measure(scaled(32, 32, 2, clean), encode(32, 32, clean))
+
+			Expect(err).Error().To(BeNil())
+			Expect(metrics.SSIM).To(BeNumerically(">", 0.9))
+		})
+	})
+
+	Context("given: content that is not an image", func() {
+		It("🧪 should: return error", func() {
+			_, err := quality.Measure([]byte("not an image"), encode(8, 8, clean))
+
+			Expect(err).Error().NotTo(BeNil())
+		})
+	})
+})
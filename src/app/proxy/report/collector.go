@@ -0,0 +1,90 @@
+package report
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// Outcome represents a single result produced for an input by a
+// particular profile.
+type Outcome struct {
+	Scheme      string
+	Profile     string
+	Destination string
+	Quality     *common.QualityMetrics
+	Err         error
+}
+
+// Sample represents an input file along with all the outcomes that
+// were produced for it.
+type Sample struct {
+	Source   string
+	Outcomes []Outcome
+}
+
+// NewCollector creates a Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		samples: make(map[string]*Sample),
+	}
+}
+
+// Collector is a common.Recorder that groups the outcomes of a run by
+// their input file. The collector is populated by the controller during
+// the principal traversal, which may be running with a worker pool, so
+// access is guarded.
+type Collector struct {
+	mx      sync.Mutex
+	samples map[string]*Sample
+}
+
+// Record adds the outcome represented by the progress message to the
+// sample of its source.
+func (c *Collector) Record(msg *common.ProgressMsg) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	sample, found := c.samples[msg.Source]
+	if !found {
+		sample = &Sample{
+			Source: msg.Source,
+		}
+		c.samples[msg.Source] = sample
+	}
+
+	sample.Outcomes = append(sample.Outcomes, Outcome{
+		Scheme:      msg.Scheme,
+		Profile:     msg.Profile,
+		Destination: msg.Destination,
+		Quality:     msg.Quality,
+		Err:         msg.Err,
+	})
+}
+
+// Samples returns the samples collected so far, ordered by source path,
+// so that the content of the report is stable regardless of the order
+// in which the items were processed.
+func (c *Collector) Samples() []*Sample {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	samples := make([]*Sample, 0, len(c.samples))
+	for _, sample := range c.samples {
+		samples = append(samples, sample)
+	}
+
+	slices.SortFunc(samples, func(a, b *Sample) int {
+		switch {
+		case a.Source < b.Source:
+			return -1
+		case a.Source > b.Source:
+			return 1
+		}
+
+		return 0
+	})
+
+	return samples
+}
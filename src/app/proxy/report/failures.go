@@ -0,0 +1,147 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/snivilised/pixa/src/locale"
+)
+
+const (
+	// maxFailureSamples is the number of paths listed for each type of
+	// failure in the summary.
+	maxFailureSamples = 3
+)
+
+// Failure is an outcome that failed, along with the input it was
+// produced for.
+type Failure struct {
+	Source  string
+	Profile string
+	Err     error
+}
+
+// FailureGroup is the set of failed outcomes whose errors are of the
+// same type. Only the first few are retained as samples.
+type FailureGroup struct {
+	Type    string
+	Count   int
+	Samples []Failure
+}
+
+// FailureSummary describes the outcomes of a run that failed.
+type FailureSummary struct {
+	Total  int
+	Failed int
+	Groups []*FailureGroup
+}
+
+// Failures groups the failed outcomes by the type of their error, largest
+// group first. An outcome that was rejected, because its quality is too
+// low or it could not be made to fit the target size, is not a failure;
+// the original is retained as intended.
+func (c *Collector) Failures() *FailureSummary {
+	summary := &FailureSummary{}
+	groups := make(map[string]*FailureGroup)
+
+	for _, sample := range c.Samples() {
+		for _, outcome := range sample.Outcomes {
+			summary.Total++
+
+			if !IsFailure(outcome.Err) {
+				continue
+			}
+
+			summary.Failed++
+			name := errorType(outcome.Err)
+			group, found := groups[name]
+
+			if !found {
+				group = &FailureGroup{
+					Type: name,
+				}
+				groups[name] = group
+				summary.Groups = append(summary.Groups, group)
+			}
+
+			group.Count++
+
+			if len(group.Samples) < maxFailureSamples {
+				group.Samples = append(group.Samples, Failure{
+					Source:  sample.Source,
+					Profile: outcome.Profile,
+					Err:     outcome.Err,
+				})
+			}
+		}
+	}
+
+	slices.SortStableFunc(summary.Groups, func(a, b *FailureGroup) int {
+		return b.Count - a.Count
+	})
+
+	return summary
+}
+
+// IsFailure determines whether the error of an outcome represents a
+// failure, as opposed to a rejection.
+func IsFailure(err error) bool {
+	var (
+		belowThreshold locale.QualityBelowThresholdErrorBehaviourQuery
+		notReached     locale.TargetSizeNotReachedErrorBehaviourQuery
+	)
+
+	return err != nil &&
+		!errors.As(err, &belowThreshold) &&
+		!errors.As(err, &notReached)
+}
+
+// untyped are the packages whose errors only describe or wrap another
+// error, rather than identifying a kind of failure.
+var untyped = []string{"errors", "fmt", "github.com/pkg/errors"}
+
+// errorType returns the name of the type of the first error in the chain
+// that has a type of its own, without its package, which is what
+// identifies the kind of failure; eg an ExecutionError, rather than the
+// ExitError it wraps. Errors created with errors.New or fmt.Errorf have no
+// type of their own.
+func errorType(err error) string {
+	for ; err != nil; err = errors.Unwrap(err) {
+		t := reflect.TypeOf(err)
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		if !slices.Contains(untyped, t.PkgPath()) {
+			return t.Name()
+		}
+	}
+
+	return "error"
+}
+
+// Summarise writes the failure summary, grouped by type of error.
+func Summarise(w io.Writer, summary *FailureSummary) {
+	fmt.Fprintf(w, "\n💥 %v of %v outcome(s) failed\n", summary.Failed, summary.Total)
+
+	for _, group := range summary.Groups {
+		fmt.Fprintf(w, "  ❌ %v (%v)\n", group.Type, group.Count)
+
+		for _, failure := range group.Samples {
+			path := failure.Source
+			if failure.Profile != "" {
+				path = fmt.Sprintf("%v [%v]", path, failure.Profile)
+			}
+
+			fmt.Fprintf(w, "    - %v: %v\n", path, strings.TrimSpace(failure.Err.Error()))
+		}
+
+		if more := group.Count - len(group.Samples); more > 0 {
+			fmt.Fprintf(w, "    ... and %v more\n", more)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package report_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+	"github.com/snivilised/pixa/src/locale"
+)
+
+var _ = Describe("Failures", func() {
+	var (
+		collector *report.Collector
+	)
+
+	BeforeEach(func() {
+		collector = report.NewCollector()
+	})
+
+	record := func(source, profile string, err error) {
+		collector.Record(&common.ProgressMsg{
+			Source:      source,
+			Destination: source + ".out",
+			Profile:     profile,
+			Err:         err,
+		})
+	}
+
+	Context("given: no failed outcomes", func() {
+		It("🧪 should: not report any failures", func() {
+			record("/pics/a.jpg", "sf", nil)
+			record("/pics/b.jpg", "sf",
+				locale.NewQualityBelowThresholdError("/pics/b.jpg.out", 0.5, 0.9),
+			)
+
+			summary := collector.Failures()
+
+			Expect(summary.Total).To(Equal(2))
+			Expect(summary.Failed).To(Equal(0))
+			Expect(summary.Groups).To(BeEmpty())
+		})
+	})
+
+	Context("given: failed outcomes", func() {
+		It("🧪 should: group failures by type of error", func() {
+			for i := range 5 {
+				record(fmt.Sprintf("/pics/%02d.jpg", i), "sf",
+					fmt.Errorf("invoke failed (%w)", &common.ExecutionError{
+						Program: "magick",
+						Err:     &exec.ExitError{},
+					}),
+				)
+			}
+
+			record("/pics/10.jpg", "sf", fmt.Errorf("could not setup: %w",
+				errors.New("skipping file: '/pics/10.jpg.out'"),
+			))
+			record("/pics/11.jpg", "sf", nil)
+
+			summary := collector.Failures()
+
+			Expect(summary.Total).To(Equal(7))
+			Expect(summary.Failed).To(Equal(6))
+			Expect(summary.Groups).To(HaveLen(2))
+
+			Expect(summary.Groups[0].Type).To(Equal("ExecutionError"))
+			Expect(summary.Groups[0].Count).To(Equal(5))
+			Expect(summary.Groups[0].Samples).To(HaveLen(3))
+			Expect(summary.Groups[0].Samples[0].Source).To(Equal("/pics/00.jpg"))
+
+			Expect(summary.Groups[1].Type).To(Equal("error"))
+			Expect(summary.Groups[1].Count).To(Equal(1))
+
+			buffer := &bytes.Buffer{}
+			report.Summarise(buffer, summary)
+
+			Expect(buffer.String()).To(ContainSubstring("6 of 7 outcome(s) failed"))
+			Expect(buffer.String()).To(ContainSubstring("ExecutionError (5)"))
+			Expect(buffer.String()).To(ContainSubstring("/pics/00.jpg [sf]"))
+			Expect(buffer.String()).To(ContainSubstring("... and 2 more"))
+		})
+	})
+
+	Context("given: wrapped errors", func() {
+		It("🧪 should: group by the first typed error", func() {
+			record("/pics/a.jpg", "sf", pkgerrors.Wrap(
+				locale.NewPartialFailureError(1, 2), "could not process",
+			))
+
+			summary := collector.Failures()
+
+			Expect(summary.Groups).To(HaveLen(1))
+			Expect(summary.Groups[0].Type).To(Equal("PartialFailureError"))
+		})
+	})
+})
@@ -0,0 +1,208 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+var (
+	//go:embed html-report.html
+	htmlContent string
+
+	htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+		"bytes": humanise,
+	}).Parse(htmlContent))
+)
+
+// Image describes a file that appears in the report
+type Image struct {
+	Path      string
+	Name      string
+	Exists    bool
+	Size      int64
+	Width     int
+	Height    int
+	Thumbnail template.URL
+}
+
+// Dimensions returns the dimensions of the image in a presentable form
+func (i *Image) Dimensions() string {
+	if i.Width == 0 || i.Height == 0 {
+		return "n/a"
+	}
+
+	return fmt.Sprintf("%v x %v", i.Width, i.Height)
+}
+
+// Result is an Image that was created from an original by a profile
+type Result struct {
+	Image
+	Scheme  string
+	Profile string
+	Savings string
+	Quality string
+	Error   string
+}
+
+// Row contains the original and all its results
+type Row struct {
+	Original Image
+	Results  []Result
+}
+
+// Mapping relates a result back to its original
+type Mapping struct {
+	Result   string `json:"result"`
+	Original string `json:"original"`
+}
+
+// Document is the model from which the html report is rendered
+type Document struct {
+	Title     string
+	Generated string
+	Rows      []Row
+	Mappings  []Mapping
+}
+
+// Build creates the report document from the samples. The file system is
+// only read from, to obtain the content of the originals and the results.
+func Build(title string, samples []*Sample, vfs storage.ReadOnlyVirtualFS) *Document {
+	doc := &Document{
+		Title:     title,
+		Generated: time.Now().Format(time.RFC1123),
+		Rows:      make([]Row, 0, len(samples)),
+	}
+
+	for _, sample := range samples {
+		row := Row{
+			Original: load(sample.Source, vfs),
+			Results:  make([]Result, 0, len(sample.Outcomes)),
+		}
+
+		for _, outcome := range sample.Outcomes {
+			result := Result{
+				Image:   load(outcome.Destination, vfs),
+				Scheme:  outcome.Scheme,
+				Profile: outcome.Profile,
+				Savings: savings(row.Original.Size, 0),
+			}
+
+			if outcome.Quality != nil {
+				result.Quality = outcome.Quality.String()
+			}
+
+			if outcome.Err != nil {
+				result.Error = outcome.Err.Error()
+			}
+
+			if result.Exists {
+				result.Savings = savings(row.Original.Size, result.Size)
+			}
+
+			row.Results = append(row.Results, result)
+		}
+
+		doc.Rows = append(doc.Rows, row)
+	}
+
+	return doc
+}
+
+// Flatten adds the mapping of each result back to its original, for when
+// the results have been flattened into a single folder, which means the
+// structure of the originals is no longer evident from the results.
+func (d *Document) Flatten() {
+	for _, row := range d.Rows {
+		for _, result := range row.Results {
+			if result.Error == "" {
+				d.Mappings = append(d.Mappings, Mapping{
+					Result:   result.Path,
+					Original: row.Original.Path,
+				})
+			}
+		}
+	}
+}
+
+// Render writes the html representation of the document
+func (d *Document) Render(w io.Writer) error {
+	return htmlTemplate.Execute(w, d)
+}
+
+// Write renders the document and writes it to the path specified.
+func Write(path string, doc *Document, vfs storage.VirtualFS) error {
+	var (
+		builder strings.Builder
+	)
+
+	if err := doc.Render(&builder); err != nil {
+		return err
+	}
+
+	if err := vfs.MkdirAll(filepath.Dir(path), common.Permissions.Write); err != nil {
+		return err
+	}
+
+	return vfs.WriteFile(path, []byte(builder.String()), common.Permissions.Beezledub)
+}
+
+func load(path string, vfs storage.ReadOnlyVirtualFS) Image {
+	img := Image{
+		Path: path,
+		Name: filepath.Base(path),
+	}
+
+	if !vfs.FileExists(path) {
+		return img
+	}
+
+	content, err := vfs.ReadFile(path)
+	if err != nil {
+		return img
+	}
+
+	pic := inspect(content)
+	img.Exists = true
+	img.Size = pic.size
+	img.Width = pic.width
+	img.Height = pic.height
+	img.Thumbnail = template.URL(pic.thumbnail) //nolint:gosec // created by us, not user input
+
+	return img
+}
+
+func savings(original, result int64) string {
+	if original == 0 || result == 0 {
+		return "n/a"
+	}
+
+	const percent = 100.0
+
+	return fmt.Sprintf("%.1f%%", float64(original-result)/float64(original)*percent)
+}
+
+func humanise(size int64) string {
+	const (
+		unit = 1024
+	)
+
+	if size < unit {
+		return fmt.Sprintf("%v B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,153 @@
+package report_test
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+)
+
+func createImage(vfs storage.VirtualFS, path string, width, height int) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255}) //nolint:gosec // test data
+		}
+	}
+
+	var buf bytes.Buffer
+	Expect(png.Encode(&buf, img)).To(Succeed())
+	Expect(vfs.WriteFile(path, buf.Bytes(), common.Permissions.Beezledub)).To(Succeed())
+}
+
+var _ = Describe("HtmlReport", func() {
+	var (
+		vfs       storage.VirtualFS
+		root      string
+		original  string
+		blur      string
+		sf        string
+		collector *report.Collector
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		root = GinkgoT().TempDir()
+		original = filepath.Join(root, "01_Backyard-Worlds-Planet-9_s01.png")
+		blur = filepath.Join(root, "blur-sf", "blur", "01_Backyard-Worlds-Planet-9_s01.png")
+		sf = filepath.Join(root, "blur-sf", "sf", "01_Backyard-Worlds-Planet-9_s01.png")
+
+		Expect(vfs.MkdirAll(filepath.Dir(blur), common.Permissions.Write)).To(Succeed())
+		createImage(vfs, original, 200, 100)
+		createImage(vfs, blur, 100, 50)
+
+		collector = report.NewCollector()
+	})
+
+	Context("given: outcomes recorded for multiple profiles", func() {
+		It("🧪 should: group the outcomes by their source", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: blur, Scheme: "blur-sf", Profile: "blur",
+			})
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: sf, Scheme: "blur-sf", Profile: "sf",
+				Err: errors.New("fake failure"),
+			})
+
+			samples := collector.Samples()
+			Expect(samples).To(HaveLen(1))
+			Expect(samples[0].Outcomes).To(HaveLen(2))
+		})
+	})
+
+	Context("given: sources recorded out of order", func() {
+		It("🧪 should: order the samples by source", func() {
+			collector.Record(&common.ProgressMsg{Source: "b.jpg", Destination: "b.out.jpg"})
+			collector.Record(&common.ProgressMsg{Source: "a.jpg", Destination: "a.out.jpg"})
+
+			samples := collector.Samples()
+			Expect(samples[0].Source).To(Equal("a.jpg"))
+			Expect(samples[1].Source).To(Equal("b.jpg"))
+		})
+	})
+
+	Context("given: a result that exists and one that does not", func() {
+		It("🧪 should: build document with sizes, savings and dimensions", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: blur, Scheme: "blur-sf", Profile: "blur",
+			})
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: sf, Scheme: "blur-sf", Profile: "sf",
+			})
+
+			doc := report.Build("test", collector.Samples(), vfs)
+			Expect(doc.Rows).To(HaveLen(1))
+
+			row := doc.Rows[0]
+			Expect(row.Original.Exists).To(BeTrue())
+			Expect(row.Original.Dimensions()).To(Equal("200 x 100"))
+			Expect(string(row.Original.Thumbnail)).To(HavePrefix("data:image/jpeg;base64,"))
+
+			Expect(row.Results[0].Exists).To(BeTrue())
+			Expect(row.Results[0].Dimensions()).To(Equal("100 x 50"))
+			Expect(row.Results[0].Savings).To(HaveSuffix("%"))
+
+			Expect(row.Results[1].Exists).To(BeFalse())
+			Expect(row.Results[1].Savings).To(Equal("n/a"))
+		})
+	})
+
+	Context("given: flattened results", func() {
+		It("🧪 should: map each result back to its original", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: blur, Scheme: "blur-sf", Profile: "blur",
+			})
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: sf, Scheme: "blur-sf", Profile: "sf",
+				Err: errors.New("fake failure"),
+			})
+
+			doc := report.Build("test", collector.Samples(), vfs)
+			doc.Flatten()
+			Expect(doc.Mappings).To(Equal([]report.Mapping{
+				{Result: blur, Original: original},
+			}))
+
+			var builder strings.Builder
+			Expect(doc.Render(&builder)).To(Succeed())
+			Expect(builder.String()).To(ContainSubstring("flattened"))
+		})
+	})
+
+	Context("given: report path", func() {
+		It("🧪 should: write self contained html", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: blur, Scheme: "blur-sf", Profile: "blur",
+				Err: errors.New("fake failure"),
+			})
+
+			path := filepath.Join(root, "reports", "pixa-report.html")
+			Expect(report.Write(path, report.Build("pixa", collector.Samples(), vfs), vfs)).To(Succeed())
+
+			content, err := vfs.ReadFile(path)
+			Expect(err).To(Succeed())
+
+			html := string(content)
+			Expect(html).To(ContainSubstring("01_Backyard-Worlds-Planet-9_s01.png"))
+			Expect(html).To(ContainSubstring("blur-sf/blur"))
+			Expect(html).To(ContainSubstring("fake failure"))
+			Expect(strings.Count(html, "data:image/jpeg;base64,")).To(Equal(2))
+		})
+	})
+})
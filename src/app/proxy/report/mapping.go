@@ -0,0 +1,74 @@
+package report
+
+import (
+	"cmp"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+)
+
+// Mappings relates each successful result of the samples back to its
+// original, without having to build the report.
+func Mappings(samples []*Sample) []Mapping {
+	mappings := []Mapping{}
+
+	for _, sample := range samples {
+		for _, outcome := range sample.Outcomes {
+			if outcome.Err == nil {
+				mappings = append(mappings, Mapping{
+					Result:   outcome.Destination,
+					Original: sample.Source,
+				})
+			}
+		}
+	}
+
+	return mappings
+}
+
+// WriteMappings writes the mappings as json to the path specified. The
+// mappings of an earlier run found at the path are retained, unless
+// replaced by a mapping of the same result, so that the results skipped
+// by an incremental or resumed run can still be traced.
+func WriteMappings(path string, mappings []Mapping, vfs storage.VirtualFS) error {
+	merged := map[string]string{}
+
+	if vfs.FileExists(path) {
+		if content, err := vfs.ReadFile(path); err == nil {
+			var previous []Mapping
+
+			if json.Unmarshal(content, &previous) == nil {
+				for _, mapping := range previous {
+					merged[mapping.Result] = mapping.Original
+				}
+			}
+		}
+	}
+
+	for _, mapping := range mappings {
+		merged[mapping.Result] = mapping.Original
+	}
+
+	all := make([]Mapping, 0, len(merged))
+	for result, original := range merged {
+		all = append(all, Mapping{Result: result, Original: original})
+	}
+
+	slices.SortFunc(all, func(a, b Mapping) int {
+		return cmp.Compare(a.Result, b.Result)
+	})
+
+	content, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := vfs.MkdirAll(filepath.Dir(path), common.Permissions.Write); err != nil {
+		return err
+	}
+
+	return vfs.WriteFile(path, content, common.Permissions.Beezledub)
+}
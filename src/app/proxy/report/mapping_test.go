@@ -0,0 +1,69 @@
+package report_test
+
+import (
+	"encoding/json"
+	"errors"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+)
+
+var _ = Describe("Mapping", func() {
+	var (
+		vfs  storage.VirtualFS
+		path string
+	)
+
+	read := func() []report.Mapping {
+		content, err := vfs.ReadFile(path)
+		Expect(err).To(Succeed())
+
+		var mappings []report.Mapping
+		Expect(json.Unmarshal(content, &mappings)).To(Succeed())
+
+		return mappings
+	}
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		path = filepath.Join(GinkgoT().TempDir(), "web", "pixa-flatten.json")
+	})
+
+	It("🧪 should: only map successful results", func() {
+		collector := report.NewCollector()
+		collector.Record(&common.ProgressMsg{Source: "/pics/2023/a.jpg", Destination: "/web/a.1234abcd.jpg"})
+		collector.Record(&common.ProgressMsg{Source: "/pics/2024/a.jpg", Destination: "/web/a.5678abcd.jpg"})
+		collector.Record(&common.ProgressMsg{
+			Source: "/pics/b.jpg", Destination: "/web/b.jpg", Err: errors.New("fake failure"),
+		})
+
+		Expect(report.WriteMappings(path, report.Mappings(collector.Samples()), vfs)).To(Succeed())
+		Expect(read()).To(Equal([]report.Mapping{
+			{Result: "/web/a.1234abcd.jpg", Original: "/pics/2023/a.jpg"},
+			{Result: "/web/a.5678abcd.jpg", Original: "/pics/2024/a.jpg"},
+		}))
+	})
+
+	When("mappings of an earlier run exist", func() {
+		It("🧪 should: retain them", func() {
+			Expect(report.WriteMappings(path, []report.Mapping{
+				{Result: "/web/a.jpg", Original: "/pics/a.jpg"},
+				{Result: "/web/b.jpg", Original: "/pics/old/b.jpg"},
+			}, vfs)).To(Succeed())
+
+			Expect(report.WriteMappings(path, []report.Mapping{
+				{Result: "/web/b.jpg", Original: "/pics/new/b.jpg"},
+			}, vfs)).To(Succeed())
+
+			Expect(read()).To(Equal([]report.Mapping{
+				{Result: "/web/a.jpg", Original: "/pics/a.jpg"},
+				{Result: "/web/b.jpg", Original: "/pics/new/b.jpg"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,152 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/quality"
+)
+
+// Standing is the aggregated performance of a profile across all the
+// samples it was applied to.
+type Standing struct {
+	Profile  string
+	NoFiles  int
+	Original int64
+	Result   int64
+	Failures int
+	measured int
+	ssim     float64
+}
+
+// Savings returns the proportion of the original bytes that were saved
+// by the profile.
+func (s *Standing) Savings() float64 {
+	if s.Original == 0 {
+		return 0
+	}
+
+	return float64(s.Original-s.Result) / float64(s.Original)
+}
+
+// SSIM returns the mean structural similarity of the results with their
+// originals; a profile whose results could not be measured has no
+// similarity.
+func (s *Standing) SSIM() float64 {
+	if s.measured == 0 {
+		return 0
+	}
+
+	return s.ssim / float64(s.measured)
+}
+
+// Score weighs the bytes saved by the quality retained, so a profile that
+// saves a lot but visibly degrades the image does not automatically win.
+func (s *Standing) Score() float64 {
+	return max(s.Savings(), 0) * max(s.SSIM(), 0)
+}
+
+// Rank scores each profile that appears in the samples and returns the
+// standings ordered from best to worst. Results that are missing or that
+// were produced with an error are counted as failures and do not
+// contribute to the score. The quality of a result is measured here if
+// it was not measured during the run.
+func Rank(samples []*Sample, vfs storage.ReadOnlyVirtualFS) []*Standing {
+	standings := make(map[string]*Standing)
+
+	for _, sample := range samples {
+		source, err := vfs.ReadFile(sample.Source)
+		if err != nil {
+			continue
+		}
+
+		for _, outcome := range sample.Outcomes {
+			standing, found := standings[outcome.Profile]
+			if !found {
+				standing = &Standing{
+					Profile: outcome.Profile,
+				}
+				standings[outcome.Profile] = standing
+			}
+
+			if outcome.Err != nil || !vfs.FileExists(outcome.Destination) {
+				standing.Failures++
+
+				continue
+			}
+
+			result, err := vfs.ReadFile(outcome.Destination)
+			if err != nil {
+				standing.Failures++
+
+				continue
+			}
+
+			standing.NoFiles++
+			standing.Original += int64(len(source))
+			standing.Result += int64(len(result))
+
+			metrics := outcome.Quality
+			if metrics == nil {
+				metrics, _ = quality.Measure(source, result)
+			}
+
+			if metrics != nil {
+				standing.measured++
+				standing.ssim += metrics.SSIM
+			}
+		}
+	}
+
+	ranked := make([]*Standing, 0, len(standings))
+	for _, standing := range standings {
+		ranked = append(ranked, standing)
+	}
+
+	slices.SortFunc(ranked, func(a, b *Standing) int {
+		switch {
+		case a.Score() > b.Score():
+			return -1
+		case a.Score() < b.Score():
+			return 1
+		}
+
+		return strings.Compare(a.Profile, b.Profile)
+	})
+
+	return ranked
+}
+
+// Tabulate writes the standings as a table, in the order provided.
+func Tabulate(w io.Writer, standings []*Standing) error {
+	const (
+		minWidth = 0
+		tabWidth = 4
+		padding  = 2
+		percent  = 100.0
+	)
+
+	tw := tabwriter.NewWriter(w, minWidth, tabWidth, padding, ' ', 0)
+
+	fmt.Fprintln(tw, "RANK\tPROFILE\tFILES\tORIGINAL\tRESULT\tSAVED\tSSIM\tSCORE\tFAILURES")
+
+	for i, s := range standings {
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%.1f%%\t%.4f\t%.4f\t%v\n",
+			i+1,
+			s.Profile,
+			s.NoFiles,
+			humanise(s.Original),
+			humanise(s.Result),
+			s.Savings()*percent,
+			s.SSIM(),
+			s.Score(),
+			s.Failures,
+		)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,95 @@
+package report_test
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2" //nolint:revive // foo
+	. "github.com/onsi/gomega"    //nolint:revive // foo
+
+	"github.com/snivilised/extendio/xfs/storage"
+	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/app/proxy/report"
+)
+
+var _ = Describe("Ranking", func() {
+	var (
+		vfs       storage.VirtualFS
+		root      string
+		original  string
+		small     string
+		large     string
+		collector *report.Collector
+	)
+
+	BeforeEach(func() {
+		vfs = storage.UseNativeFS()
+		root = GinkgoT().TempDir()
+		original = filepath.Join(root, "01_Backyard-Worlds-Planet-9_s01.png")
+		small = filepath.Join(root, "adaptive-sf", "adaptive", "01_Backyard-Worlds-Planet-9_s01.png")
+		large = filepath.Join(root, "adaptive-sf", "sf", "01_Backyard-Worlds-Planet-9_s01.png")
+
+		Expect(vfs.MkdirAll(filepath.Dir(small), common.Permissions.Write)).To(Succeed())
+		Expect(vfs.MkdirAll(filepath.Dir(large), common.Permissions.Write)).To(Succeed())
+		createImage(vfs, original, 200, 100)
+		createImage(vfs, small, 100, 50)
+		createImage(vfs, large, 200, 100)
+
+		collector = report.NewCollector()
+	})
+
+	Context("given: profiles with different savings", func() {
+		It("🧪 should: rank the profile with the best score first", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: large, Scheme: "adaptive-sf", Profile: "sf",
+			})
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: small, Scheme: "adaptive-sf", Profile: "adaptive",
+			})
+
+			standings := report.Rank(collector.Samples(), vfs)
+			Expect(standings).To(HaveLen(2))
+			Expect(standings[0].Profile).To(Equal("adaptive"))
+			Expect(standings[0].Savings()).To(BeNumerically(">", 0))
+			Expect(standings[0].SSIM()).To(BeNumerically(">", 0))
+			Expect(standings[1].Profile).To(Equal("sf"))
+			Expect(standings[1].Score()).To(BeNumerically("~", 0, 0.0001))
+		})
+	})
+
+	Context("given: failed outcome", func() {
+		It("🧪 should: count failure without contributing to score", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: small, Scheme: "adaptive-sf", Profile: "adaptive",
+				Err: errors.New("fake failure"),
+			})
+
+			standings := report.Rank(collector.Samples(), vfs)
+			Expect(standings).To(HaveLen(1))
+			Expect(standings[0].Failures).To(Equal(1))
+			Expect(standings[0].NoFiles).To(Equal(0))
+			Expect(standings[0].Score()).To(BeNumerically("==", 0))
+		})
+	})
+
+	Context("given: standings", func() {
+		It("🧪 should: tabulate in rank order", func() {
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: large, Scheme: "adaptive-sf", Profile: "sf",
+			})
+			collector.Record(&common.ProgressMsg{
+				Source: original, Destination: small, Scheme: "adaptive-sf", Profile: "adaptive",
+			})
+
+			var builder strings.Builder
+			Expect(report.Tabulate(&builder, report.Rank(collector.Samples(), vfs))).To(Succeed())
+
+			lines := strings.Split(strings.TrimSpace(builder.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(HavePrefix("RANK"))
+			Expect(lines[1]).To(MatchRegexp(`^1\s+adaptive`))
+			Expect(lines[2]).To(MatchRegexp(`^2\s+sf`))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+package report
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/jpeg"
+
+	// register the decoders for the formats pixa is able to report on
+	//
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	thumbnailEdge    = 160
+	thumbnailQuality = 75
+)
+
+// picture is the information that is extracted from an image file to
+// be presented in the report.
+type picture struct {
+	size      int64
+	width     int
+	height    int
+	thumbnail string
+}
+
+// inspect decodes the content of an image file. Files that can't be
+// decoded (eg the result of a dummy run) are not an error, they just
+// result in a picture without dimensions or a thumbnail.
+func inspect(content []byte) picture {
+	pic := picture{
+		size: int64(len(content)),
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(content))
+	if err != nil {
+		return pic
+	}
+
+	bounds := img.Bounds()
+	pic.width = bounds.Dx()
+	pic.height = bounds.Dy()
+	pic.thumbnail = thumbnail(img)
+
+	return pic
+}
+
+// thumbnail creates a data url of a scaled down version of the image, so that
+// the report is self contained and can be viewed without access to the
+// images it refers to.
+func thumbnail(img image.Image) string {
+	scaled := scale(img, thumbnailEdge)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return ""
+	}
+
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+// scale creates a copy of the image whose longest edge does not exceed
+// the edge specified, using nearest neighbour sampling; this is more
+// than adequate for a thumbnail.
+func scale(img image.Image, edge int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	longest := max(width, height)
+
+	if longest <= edge {
+		return img
+	}
+
+	w := max(1, width*edge/longest)
+	h := max(1, height*edge/longest)
+	scaled := image.NewRGBA(image.Rect(0, 0, w, h))
+
+	for y := 0; y < h; y++ {
+		sy := bounds.Min.Y + y*height/h
+
+		for x := 0; x < w; x++ {
+			sx := bounds.Min.X + x*width/w
+			scaled.Set(x, y, img.At(sx, sy))
+		}
+	}
+
+	return scaled
+}
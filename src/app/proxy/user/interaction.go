@@ -2,6 +2,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
@@ -97,6 +98,48 @@ func (u *interaction) navigate(ci common.ClientTraverseInfo,
 	return result, err
 }
 
+// observe logs the failure of the program, if that is why the job failed,
+// along with the output it produced.
+func (u *interaction) observe(msg *common.ProgressMsg) {
+	var execErr *common.ExecutionError
+
+	if errors.As(msg.Err, &execErr) {
+		u.logger.Error("execution failed",
+			slog.String("source", msg.Source),
+			slog.String("profile", msg.Profile),
+			slog.Any("execution", execErr),
+		)
+	}
+}
+
+// errorView presents the error of a job. When the program failed, each
+// line of its stderr is listed beneath the error.
+func errorView(err error) string {
+	if err == nil {
+		return "💫 ok"
+	}
+
+	var execErr *common.ExecutionError
+
+	if !errors.As(err, &execErr) {
+		return fmt.Sprintf("💥 %v", err)
+	}
+
+	content := fmt.Sprintf("💥 '%v' exited with code %v", execErr.Program, execErr.ExitCode)
+
+	for _, line := range execErr.StderrLines() {
+		content += fmt.Sprintf(`
+		-->      stderr: %v`, line)
+	}
+
+	if execErr.Truncated {
+		content += `
+		-->      stderr: ...`
+	}
+
+	return content
+}
+
 func summary(result *nav.TraverseResult, err error) string {
 	measure := fmt.Sprintf("started: '%v', elapsed: '%v'",
 		result.Session.StartedAt().Format(time.RFC1123), result.Session.Elapsed(),
@@ -143,3 +186,14 @@ func clearResumeFromWith(with nav.CreateNewRunnerWith) nav.CreateNewRunnerWith {
 	//
 	return (with &^ nav.RunnerWithResume)
 }
+
+// NewLinearInteraction creates the interaction for entries that do not
+// traverse, eg apply. The textual ui is driven by the traversal, so
+// their progress can only be presented linearly.
+func NewLinearInteraction(logger *slog.Logger) common.UserInteraction {
+	return &linearUI{
+		interaction: interaction{
+			logger: logger,
+		},
+	}
+}
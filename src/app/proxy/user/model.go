@@ -12,6 +12,7 @@ import (
 	"github.com/samber/lo"
 	"github.com/snivilised/extendio/xfs/nav"
 	"github.com/snivilised/pixa/src/app/proxy/common"
+	"github.com/snivilised/pixa/src/locale"
 )
 
 type actionType string
@@ -21,6 +22,8 @@ type JobDescription struct {
 	Profile     string
 	Source      string
 	Destination string
+	Quality     *common.QualityMetrics
+	Attempt     *common.TargetAttempt
 	emoji       string
 	err         error
 }
@@ -116,11 +119,22 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, principal(m.di, m.ui)
 
 	case *common.ProgressMsg:
+		m.latest.Attempt = msg.Attempt
+
+		if msg.Attempt != nil {
+			// interim, the job is still in progress
+			//
+			m.status = "🎯 searching"
+
+			break
+		}
+
 		atomic.AddInt32(&m.level, 1)
 		m.latest.Source = msg.Source
 		m.latest.Destination = msg.Destination
 		m.latest.Scheme = msg.Scheme
 		m.latest.Profile = msg.Profile
+		m.latest.Quality = msg.Quality
 		m.latest.emoji = randemoji()
 		m.latest.err = msg.Err
 		m.status = "🚀 progressing"
@@ -141,6 +155,8 @@ func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
+			m.err = locale.NewUserAbortError()
+
 			return m, tea.Quit
 		}
 	}
@@ -152,6 +168,8 @@ type bodyContent struct {
 	source      string
 	destination string
 	emoji       string
+	quality     *common.QualityMetrics
+	attempt     *common.TargetAttempt
 }
 
 func (bc *bodyContent) view() string {
@@ -170,6 +188,18 @@ func (bc *bodyContent) view() string {
 		to,
 	)
 
+	if bc.quality != nil {
+		content += fmt.Sprintf(`
+		-->     quality: %v`, bc.quality)
+	}
+
+	if bc.attempt != nil {
+		content += fmt.Sprintf(`
+		-->     attempt: #%v quality: %v, size: %v bytes, target: %v bytes`,
+			bc.attempt.No, bc.attempt.Quality, bc.attempt.Size, bc.attempt.Target,
+		)
+	}
+
 	return content
 }
 
@@ -205,12 +235,11 @@ func (m *model) View() string {
 		source:      m.latest.Source,
 		destination: m.latest.Destination,
 		emoji:       m.latest.emoji,
+		quality:     m.latest.Quality,
+		attempt:     m.latest.Attempt,
 	}
 
-	e := lo.Ternary(m.latest.err != nil,
-		fmt.Sprintf("💥 %v", m.latest.err),
-		"💫 ok",
-	)
+	e := errorView(m.latest.err)
 	latestView := fmt.Sprintf(
 		`
 	- %v status(%v): %v
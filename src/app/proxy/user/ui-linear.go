@@ -37,17 +37,28 @@ func (ui *linearUI) Traverse(di common.DriverTraverseInfo) (*nav.TraverseResult,
 // Tick allows the model to be updated, as activity occurs during
 // the traversal.
 func (ui *linearUI) Tick(msg *common.ProgressMsg) {
+	ui.observe(msg)
+
 	bc := bodyContent{
 		source:      msg.Source,
 		destination: msg.Destination,
 		emoji:       randemoji(),
+		quality:     msg.Quality,
+		attempt:     msg.Attempt,
+	}
+
+	content := bc.view()
+
+	if msg.Err != nil {
+		content += fmt.Sprintf(`
+		-->       error: %v`, errorView(msg.Err))
 	}
 
 	fmt.Printf(
 		`
 	===
 %v`,
-		bc.view(),
+		content,
 	)
 }
 
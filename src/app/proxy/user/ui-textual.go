@@ -1,6 +1,7 @@
 package user
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -63,7 +64,7 @@ func (ui *textualUI) Traverse(di common.DriverTraverseInfo,
 	ui.m.program = tea.NewProgram(ui.m, options...)
 
 	if _, err := ui.m.program.Run(); err != nil {
-		ui.logger.Error("could not start: '%v'", err)
+		ui.logger.Error("could not start", slog.String("error", err.Error()))
 
 		return nil, err
 	}
@@ -74,6 +75,8 @@ func (ui *textualUI) Traverse(di common.DriverTraverseInfo,
 // Tick allows the model to be updated, as activity occurs during
 // the traversal.
 func (ui *textualUI) Tick(msg *common.ProgressMsg) {
+	ui.observe(msg)
+
 	if ui.m.delay > 0 {
 		time.Sleep(ui.m.delay)
 	}
@@ -111,12 +111,13 @@ func init() {
 	}
 
 	AdvancedConfigData = &cfg.MsAdvancedConfig{
-		Abort: false,
+		Policy: "continue",
 		LabelsCFG: cfg.MsLabelsConfig{
 			Adhoc:   "ADHOC",
 			Journal: "journal",
 			Legacy:  ".LEGACY",
 			Trash:   "TRASH",
+			Failed:  "FAILED",
 			Fake:    ".FAKE",
 		},
 		ExtensionsCFG: cfg.MsExtensionsConfig{
@@ -153,6 +153,7 @@ func ReadGlobalConfig(configPath string) error {
 }
 
 // MockConfigFile create a dummy config file in the file system specified
+// and mirror the config files found at configPath into it
 func MockConfigFile(vfs storage.VirtualFS, configPath string) error {
 	var (
 		err error
@@ -168,6 +169,22 @@ func MockConfigFile(vfs storage.VirtualFS, configPath string) error {
 
 	gomega.Expect(matchers.AsDirectory(configPath)).To(matchers.ExistInFS(vfs))
 
+	// viper reads the config from the native file system, but the config
+	// layers are read through the vfs, so they must find the same content.
+	//
+	files, _ := filepath.Glob(filepath.Join(configPath, "*."+common.Definitions.Pixa.ConfigType))
+
+	for _, file := range files {
+		content, e := os.ReadFile(file)
+		if e != nil {
+			ginkgo.Fail(fmt.Sprintf("🔥 can't read config (err: '%v')", e))
+		}
+
+		if e := vfs.WriteFile(file, content, common.Permissions.Write); e != nil {
+			ginkgo.Fail(fmt.Sprintf("🔥 can't mirror config (err: '%v')", e))
+		}
+	}
+
 	return err
 }
 
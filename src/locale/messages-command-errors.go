@@ -113,3 +113,282 @@ func NewOutputPathDoesNotExistError(path string) OutputPathDoesNotExistError {
 		},
 	}
 }
+
+// ConfigCmdScopeInvalidTemplData
+// ❌
+type ConfigCmdScopeInvalidTemplData struct {
+	pixaTemplData
+	Value      string
+	Acceptable string
+}
+
+func (td ConfigCmdScopeInvalidTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-cmd-scope-invalid.error",
+		Description: "config command scope failed validation",
+		Other:       "invalid config scope value: {{.Value}}, acceptable: {{.Acceptable}}",
+	}
+}
+
+// InvalidConfigScopeErrorBehaviourQuery used to query if an error is:
+// "invalid config scope value"
+type InvalidConfigScopeErrorBehaviourQuery interface {
+	ConfigScopeValidationFailure() bool
+}
+
+type InvalidConfigScopeError struct {
+	xi18n.LocalisableError
+}
+
+func NewInvalidConfigScopeError(value, acceptable string) InvalidConfigScopeError {
+	return InvalidConfigScopeError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: ConfigCmdScopeInvalidTemplData{
+				Value:      value,
+				Acceptable: acceptable,
+			},
+		},
+	}
+}
+
+// ShrinkCmdErrorPolicyInvalidTemplData
+// ❌
+type ShrinkCmdErrorPolicyInvalidTemplData struct {
+	pixaTemplData
+	Value      string
+	Acceptable string
+}
+
+func (td ShrinkCmdErrorPolicyInvalidTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-error-policy-invalid.error",
+		Description: "shrink command error policy failed validation",
+		Other:       "invalid error policy value: {{.Value}}, acceptable: {{.Acceptable}}",
+	}
+}
+
+// InvalidErrorPolicyErrorBehaviourQuery used to query if an error is:
+// "invalid error policy value"
+type InvalidErrorPolicyErrorBehaviourQuery interface {
+	ErrorPolicyValidationFailure() bool
+}
+
+type InvalidErrorPolicyError struct {
+	xi18n.LocalisableError
+}
+
+func NewInvalidErrorPolicyError(value, acceptable string) InvalidErrorPolicyError {
+	return InvalidErrorPolicyError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: ShrinkCmdErrorPolicyInvalidTemplData{
+				Value:      value,
+				Acceptable: acceptable,
+			},
+		},
+	}
+}
+
+// DupesCmdActionInvalidTemplData
+// ❌
+type DupesCmdActionInvalidTemplData struct {
+	pixaTemplData
+	Value      string
+	Acceptable string
+}
+
+func (td DupesCmdActionInvalidTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-cmd-action-invalid.error",
+		Description: "dupes command action failed validation",
+		Other:       "invalid dupes action value: {{.Value}}, acceptable: {{.Acceptable}}",
+	}
+}
+
+// InvalidDupesActionErrorBehaviourQuery used to query if an error is:
+// "invalid dupes action value"
+type InvalidDupesActionErrorBehaviourQuery interface {
+	DupesActionValidationFailure() bool
+}
+
+type InvalidDupesActionError struct {
+	xi18n.LocalisableError
+}
+
+func NewInvalidDupesActionError(value, acceptable string) InvalidDupesActionError {
+	return InvalidDupesActionError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: DupesCmdActionInvalidTemplData{
+				Value:      value,
+				Acceptable: acceptable,
+			},
+		},
+	}
+}
+
+// RootCmdDryRunInvalidTemplData
+// ❌
+type RootCmdDryRunInvalidTemplData struct {
+	pixaTemplData
+	Value      string
+	Acceptable string
+}
+
+func (td RootCmdDryRunInvalidTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "root-cmd-dry-run-invalid.error",
+		Description: "root command dry run failed validation",
+		Other:       "invalid dry run value: {{.Value}}, acceptable: {{.Acceptable}}",
+	}
+}
+
+// InvalidDryRunErrorBehaviourQuery used to query if an error is:
+// "invalid dry run value"
+type InvalidDryRunErrorBehaviourQuery interface {
+	DryRunValidationFailure() bool
+}
+
+type InvalidDryRunError struct {
+	xi18n.LocalisableError
+}
+
+func NewInvalidDryRunError(value, acceptable string) InvalidDryRunError {
+	return InvalidDryRunError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: RootCmdDryRunInvalidTemplData{
+				Value:      value,
+				Acceptable: acceptable,
+			},
+		},
+	}
+}
+
+// ShrinkCmdFlattenRequiresOutputTemplData
+// ❌
+type ShrinkCmdFlattenRequiresOutputTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdFlattenRequiresOutputTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-flatten-requires-output.error",
+		Description: "shrink command flatten specified without output",
+		Other:       "flatten requires output to be specified",
+	}
+}
+
+// FlattenRequiresOutputErrorBehaviourQuery used to query if an error is:
+// "flatten requires output"
+type FlattenRequiresOutputErrorBehaviourQuery interface {
+	FlattenRequiresOutput() bool
+}
+
+type FlattenRequiresOutputError struct {
+	xi18n.LocalisableError
+}
+
+func NewFlattenRequiresOutputError() FlattenRequiresOutputError {
+	return FlattenRequiresOutputError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: ShrinkCmdFlattenRequiresOutputTemplData{},
+		},
+	}
+}
+
+// RecommendCmdSchemeRequiredTemplData
+// ❌
+type RecommendCmdSchemeRequiredTemplData struct {
+	pixaTemplData
+	Command string
+}
+
+func (td RecommendCmdSchemeRequiredTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-cmd-scheme-required.error",
+		Description: "recommend command specified without scheme",
+		Other:       "{{.Command}} requires a scheme",
+	}
+}
+
+// SchemeRequiredErrorBehaviourQuery used to query if an error is:
+// "requires a scheme"
+type SchemeRequiredErrorBehaviourQuery interface {
+	SchemeRequired() bool
+}
+
+type SchemeRequiredError struct {
+	xi18n.LocalisableError
+}
+
+func NewSchemeRequiredError(command string) SchemeRequiredError {
+	return SchemeRequiredError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: RecommendCmdSchemeRequiredTemplData{
+				Command: command,
+			},
+		},
+	}
+}
+
+// RecommendCmdNoRecommendationTemplData
+// ❌
+type RecommendCmdNoRecommendationTemplData struct {
+	pixaTemplData
+}
+
+func (td RecommendCmdNoRecommendationTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-cmd-no-recommendation.error",
+		Description: "recommend command found no profile with a measurable result",
+		Other:       "no profile produced a result that could be recommended",
+	}
+}
+
+// NoRecommendationErrorBehaviourQuery used to query if an error is:
+// "no profile produced a result that could be recommended"
+type NoRecommendationErrorBehaviourQuery interface {
+	NoRecommendation() bool
+}
+
+type NoRecommendationError struct {
+	xi18n.LocalisableError
+}
+
+func NewNoRecommendationError() NoRecommendationError {
+	return NoRecommendationError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: RecommendCmdNoRecommendationTemplData{},
+		},
+	}
+}
+
+// RecommendCmdNoConfigFileTemplData
+// ❌
+type RecommendCmdNoConfigFileTemplData struct {
+	pixaTemplData
+}
+
+func (td RecommendCmdNoConfigFileTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-cmd-no-config-file.error",
+		Description: "recommend command has no config file to apply recommendation to",
+		Other:       "can't apply recommendation, config file not found",
+	}
+}
+
+// NoConfigFileErrorBehaviourQuery used to query if an error is:
+// "config file not found"
+type NoConfigFileErrorBehaviourQuery interface {
+	NoConfigFile() bool
+}
+
+type NoConfigFileError struct {
+	xi18n.LocalisableError
+}
+
+func NewNoConfigFileError() NoConfigFileError {
+	return NoConfigFileError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: RecommendCmdNoConfigFileTemplData{},
+		},
+	}
+}
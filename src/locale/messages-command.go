@@ -70,6 +70,20 @@ func (td RootCmdLangUsageTemplData) Message() *i18n.Message {
 	}
 }
 
+// RootCmdDryRunParamUsageTemplData
+// 🧊
+type RootCmdDryRunParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td RootCmdDryRunParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "root-command-dry-run.param-usage",
+		Description: "root command dry run flag usage",
+		Other:       "dry-run allows the user to see the effects of a command without running it; =simulate runs it against a copy of the directory tree and shows the resulting changes",
+	}
+}
+
 // RootCmdFolderRexExParamUsageTemplData
 // 🧊
 type RootCmdFolderRexExParamUsageTemplData struct {
@@ -281,3 +295,703 @@ func (td ShrinkLongDefinitionTemplData) Message() *i18n.Message {
 		Other:       "Directory tree based bulk image processor (using ImageMagick)",
 	}
 }
+
+// ShrinkCmdFlattenParamUsageTemplData
+// 🧊
+type ShrinkCmdFlattenParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdFlattenParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-flatten.param-usage",
+		Description: "shrink command flatten flag usage",
+		Other:       "flatten sends every result directly to the output, without the sub-path of its input; results of inputs with the same name are told apart by a short hash of the sub-path",
+	}
+}
+
+// ShrinkCmdIncrementalParamUsageTemplData
+// 🧊
+type ShrinkCmdIncrementalParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdIncrementalParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-incremental.param-usage",
+		Description: "shrink command incremental flag usage",
+		Other:       "incremental skips images that are results of an earlier run by pixa and have not changed since, so they are not compressed again",
+	}
+}
+
+// ShrinkCmdForceParamUsageTemplData
+// 🧊
+type ShrinkCmdForceParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdForceParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-force.param-usage",
+		Description: "shrink command force flag usage",
+		Other:       "force processes every image, including those that incremental would skip, without reusing cached results",
+	}
+}
+
+// ShrinkCmdLinkParamUsageTemplData
+// 🧊
+type ShrinkCmdLinkParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdLinkParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-link.param-usage",
+		Description: "shrink command link flag usage",
+		Other:       "link hard links the cached result of an identical image, instead of copying it",
+	}
+}
+
+// ShrinkCmdHTMLReportParamUsageTemplData
+// 🧊
+type ShrinkCmdHTMLReportParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdHTMLReportParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-html-report.param-usage",
+		Description: "shrink command html-report flag usage",
+		Other:       "html-report writes a self contained html page comparing originals with their results",
+	}
+}
+
+// ReportCmdShortDefinitionTemplData
+// 🧊
+type ReportCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ReportCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "report-command.short-description",
+		Description: "Short description for report command",
+		Other:       "html comparison report of originals and results",
+	}
+}
+
+// ReportLongDefinitionTemplData
+// 🧊
+type ReportLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ReportLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "report-command.long-description",
+		Description: "Long description for report command",
+		Other:       "Generates a static html page comparing each original image with the results produced for it by each profile, showing thumbnails, byte sizes, savings and dimensions",
+	}
+}
+
+// ReportCmdOutputPathParamUsageTemplData
+// 🧊
+type ReportCmdOutputPathParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ReportCmdOutputPathParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "report-cmd-output-path.param-usage",
+		Description: "report command output path flag usage",
+		Other:       "output is the location the results were written to by a previous shrink",
+	}
+}
+
+// ReportCmdReportFileParamUsageTemplData
+// 🧊
+type ReportCmdReportFileParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ReportCmdReportFileParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "report-cmd-report-file.param-usage",
+		Description: "report command report file flag usage",
+		Other:       "report-file is the path of the html file to create",
+	}
+}
+
+// ShrinkCmdMinSSIMParamUsageTemplData
+// 🧊
+type ShrinkCmdMinSSIMParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdMinSSIMParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-min-ssim.param-usage",
+		Description: "shrink command min-ssim flag usage",
+		Other:       "min-ssim rejects results whose structural similarity with the original is below this threshold, keeping the original",
+	}
+}
+
+// RecommendCmdShortDefinitionTemplData
+// 🧊
+type RecommendCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td RecommendCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-command.short-description",
+		Description: "Short description for recommend command",
+		Other:       "rank the profiles of a scheme from a sample run",
+	}
+}
+
+// RecommendLongDefinitionTemplData
+// 🧊
+type RecommendLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td RecommendLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-command.long-description",
+		Description: "Long description for recommend command",
+		Other:       "Runs a sample with each profile in the scheme, scores each profile by the bytes saved weighed against the structural similarity of its results and prints a ranked table; optionally writes the winning profile into the config as the default",
+	}
+}
+
+// RecommendCmdOutputPathParamUsageTemplData
+// 🧊
+type RecommendCmdOutputPathParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td RecommendCmdOutputPathParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-cmd-output-path.param-usage",
+		Description: "recommend command output path flag usage",
+		Other:       "output creates the sample results under this location instead of alongside the originals",
+	}
+}
+
+// RecommendCmdApplyParamUsageTemplData
+// 🧊
+type RecommendCmdApplyParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td RecommendCmdApplyParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "recommend-cmd-apply.param-usage",
+		Description: "recommend command apply flag usage",
+		Other:       "apply writes the winning profile into the config as the default profile",
+	}
+}
+
+// ShrinkCmdTargetSizeParamUsageTemplData
+// 🧊
+type ShrinkCmdTargetSizeParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdTargetSizeParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-target-size.param-usage",
+		Description: "shrink command target-size flag usage",
+		Other:       "target-size searches for the highest quality that produces a result within this size (eg 500KB, 1.5MiB)",
+	}
+}
+
+// ShrinkCmdErrorPolicyParamUsageTemplData
+// 🧊
+type ShrinkCmdErrorPolicyParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdErrorPolicyParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-error-policy.param-usage",
+		Description: "shrink command error-policy flag usage",
+		Other:       "error-policy determines what happens when an item fails: abort, continue or quarantine (moves the input into $FAILED$)",
+	}
+}
+
+// ShrinkCmdMaxFailuresParamUsageTemplData
+// 🧊
+type ShrinkCmdMaxFailuresParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdMaxFailuresParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-max-failures.param-usage",
+		Description: "shrink command max-failures flag usage",
+		Other:       "max-failures aborts the run once this many items have failed (0: no limit)",
+	}
+}
+
+// ShrinkCmdPlanFileParamUsageTemplData
+// 🧊
+type ShrinkCmdPlanFileParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdPlanFileParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-plan-file.param-usage",
+		Description: "shrink command plan-file flag usage",
+		Other:       "plan-file writes the plan of a dry run (command lines, moves and results) to this file, instead of stdout",
+	}
+}
+
+// ShrinkCmdPlanParamUsageTemplData
+// 🧊
+type ShrinkCmdPlanParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ShrinkCmdPlanParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "shrink-cmd-plan.param-usage",
+		Description: "shrink command plan flag usage",
+		Other:       "plan performs a dry run, writing every planned operation to this (json) file, so that it can be executed later by apply",
+	}
+}
+
+// ApplyCmdShortDefinitionTemplData
+// 🧊
+type ApplyCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ApplyCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "apply-command.short-description",
+		Description: "Short description for apply command",
+		Other:       "execute a plan created by shrink --plan",
+	}
+}
+
+// ApplyLongDefinitionTemplData
+// 🧊
+type ApplyLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ApplyLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "apply-command.long-description",
+		Description: "Long description for apply command",
+		Other:       "Executes exactly the operations of a plan created by shrink --plan, with the worker pool. An input that has changed since it was planned (size, modification time or content) is not processed",
+	}
+}
+
+// ProfilesCmdShortDefinitionTemplData
+// 🧊
+type ProfilesCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ProfilesCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "profiles-command.short-description",
+		Description: "Short description for profiles command",
+		Other:       "inspect the profiles defined in config",
+	}
+}
+
+// ProfilesLongDefinitionTemplData
+// 🧊
+type ProfilesLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ProfilesLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "profiles-command.long-description",
+		Description: "Long description for profiles command",
+		Other:       "Inspect the profiles defined in config, as declared or as resolved, ie with the profiles they extend merged in",
+	}
+}
+
+// ProfilesShowCmdShortDefinitionTemplData
+// 🧊
+type ProfilesShowCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ProfilesShowCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "profiles-show-command.short-description",
+		Description: "Short description for profiles show command",
+		Other:       "show a profile defined in config",
+	}
+}
+
+// ProfilesShowCmdResolvedParamUsageTemplData
+// 🧊
+type ProfilesShowCmdResolvedParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ProfilesShowCmdResolvedParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "profiles-show-cmd-resolved.param-usage",
+		Description: "profiles show command resolved flag usage",
+		Other:       "resolved shows the effective flags of the profile, with the profiles it extends merged in",
+	}
+}
+
+// ProfilesListCmdShortDefinitionTemplData
+// 🧊
+type ProfilesListCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ProfilesListCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "profiles-list-command.short-description",
+		Description: "Short description for profiles list command",
+		Other:       "list the profiles defined in config with the command line each produces",
+	}
+}
+
+// ProfilesValidateCmdShortDefinitionTemplData
+// 🧊
+type ProfilesValidateCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ProfilesValidateCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "profiles-validate-command.short-description",
+		Description: "Short description for profiles validate command",
+		Other:       "validate every profile defined in config",
+	}
+}
+
+// SchemesCmdShortDefinitionTemplData
+// 🧊
+type SchemesCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td SchemesCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "schemes-command.short-description",
+		Description: "Short description for schemes command",
+		Other:       "inspect the schemes defined in config",
+	}
+}
+
+// SchemesLongDefinitionTemplData
+// 🧊
+type SchemesLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td SchemesLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "schemes-command.long-description",
+		Description: "Long description for schemes command",
+		Other:       "Inspect the schemes defined in config, the profiles they contain and the command line each of those profiles produces",
+	}
+}
+
+// SchemesListCmdShortDefinitionTemplData
+// 🧊
+type SchemesListCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td SchemesListCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "schemes-list-command.short-description",
+		Description: "Short description for schemes list command",
+		Other:       "list the schemes defined in config with their profiles",
+	}
+}
+
+// SchemesShowCmdShortDefinitionTemplData
+// 🧊
+type SchemesShowCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td SchemesShowCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "schemes-show-command.short-description",
+		Description: "Short description for schemes show command",
+		Other:       "show the profiles of a scheme with the command line each produces",
+	}
+}
+
+// SchemesValidateCmdShortDefinitionTemplData
+// 🧊
+type SchemesValidateCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td SchemesValidateCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "schemes-validate-command.short-description",
+		Description: "Short description for schemes validate command",
+		Other:       "validate every scheme defined in config",
+	}
+}
+
+// ConfigCmdShortDefinitionTemplData
+// 🧊
+type ConfigCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-command.short-description",
+		Description: "Short description for config command",
+		Other:       "manage the pixa config file",
+	}
+}
+
+// ConfigLongDefinitionTemplData
+// 🧊
+type ConfigLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-command.long-description",
+		Description: "Long description for config command",
+		Other:       "Manage the pixa config file; create it, find out which file is in use and show the effective values along with where they come from",
+	}
+}
+
+// ConfigInitCmdShortDefinitionTemplData
+// 🧊
+type ConfigInitCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigInitCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-init-command.short-description",
+		Description: "Short description for config init command",
+		Other:       "write the default config to the chosen scope",
+	}
+}
+
+// ConfigInitCmdScopeParamUsageTemplData
+// 🧊
+type ConfigInitCmdScopeParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigInitCmdScopeParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-init-cmd-scope.param-usage",
+		Description: "config init command scope flag usage",
+		Other:       "scope is the location the config file is written to (default, home, xdg, platform, env)",
+	}
+}
+
+// ConfigInitCmdForceParamUsageTemplData
+// 🧊
+type ConfigInitCmdForceParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigInitCmdForceParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-init-cmd-force.param-usage",
+		Description: "config init command force flag usage",
+		Other:       "force overwrites the config file if it already exists",
+	}
+}
+
+// ConfigShowCmdShortDefinitionTemplData
+// 🧊
+type ConfigShowCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigShowCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-show-command.short-description",
+		Description: "Short description for config show command",
+		Other:       "show the config in use",
+	}
+}
+
+// ConfigShowCmdEffectiveParamUsageTemplData
+// 🧊
+type ConfigShowCmdEffectiveParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigShowCmdEffectiveParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-show-cmd-effective.param-usage",
+		Description: "config show command effective flag usage",
+		Other:       "effective shows each of the merged config values along with its source",
+	}
+}
+
+// ConfigPathCmdShortDefinitionTemplData
+// 🧊
+type ConfigPathCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigPathCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-path-command.short-description",
+		Description: "Short description for config path command",
+		Other:       "show the paths searched for the config file and the one in use",
+	}
+}
+
+// ConfigEditCmdShortDefinitionTemplData
+// 🧊
+type ConfigEditCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigEditCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-edit-command.short-description",
+		Description: "Short description for config edit command",
+		Other:       "open the config file in use with the editor defined by VISUAL or EDITOR",
+	}
+}
+
+// ConfigMigrateCmdShortDefinitionTemplData
+// 🧊
+type ConfigMigrateCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigMigrateCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-migrate-command.short-description",
+		Description: "Short description for config migrate command",
+		Other:       "upgrade the config file in use to the current layout, retaining a backup",
+	}
+}
+
+// ConfigLintCmdShortDefinitionTemplData
+// 🧊
+type ConfigLintCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigLintCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-lint-command.short-description",
+		Description: "Short description for config lint command",
+		Other:       "check a config file for unknown keys, values of the wrong type and invalid suffixes",
+	}
+}
+
+// ConfigSchemaCmdShortDefinitionTemplData
+// 🧊
+type ConfigSchemaCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td ConfigSchemaCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "config-schema-command.short-description",
+		Description: "Short description for config schema command",
+		Other:       "print the JSON Schema of the config file",
+	}
+}
+
+// DupesCmdShortDefinitionTemplData
+// 🧊
+type DupesCmdShortDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td DupesCmdShortDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-command.short-description",
+		Description: "Short description for dupes command",
+		Other:       "find duplicate and near-duplicate images",
+	}
+}
+
+// DupesLongDefinitionTemplData
+// 🧊
+type DupesLongDefinitionTemplData struct {
+	pixaTemplData
+}
+
+func (td DupesLongDefinitionTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-command.long-description",
+		Description: "Long description for dupes command",
+		Other:       "Fingerprints every image in the directory, using the same traversal and filters as shrink, then reports the groups of exact duplicates (identical content) and near duplicates (similar difference and perceptual hashes). Optionally, the extra copies of exact duplicates can be moved to trash or replaced with hard links",
+	}
+}
+
+// DupesCmdThresholdParamUsageTemplData
+// 🧊
+type DupesCmdThresholdParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td DupesCmdThresholdParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-cmd-threshold.param-usage",
+		Description: "dupes command threshold flag usage",
+		Other:       "threshold is the maximum number of bits (out of 64) by which the perceptual hashes of near duplicates may differ",
+	}
+}
+
+// DupesCmdExactParamUsageTemplData
+// 🧊
+type DupesCmdExactParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td DupesCmdExactParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-cmd-exact.param-usage",
+		Description: "dupes command exact flag usage",
+		Other:       "exact only finds duplicates with identical content",
+	}
+}
+
+// DupesCmdActionParamUsageTemplData
+// 🧊
+type DupesCmdActionParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td DupesCmdActionParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-cmd-action.param-usage",
+		Description: "dupes command action flag usage",
+		Other:       "action applied to the extra copies of exact duplicates (none|trash|link)",
+	}
+}
+
+// DupesCmdReportFileParamUsageTemplData
+// 🧊
+type DupesCmdReportFileParamUsageTemplData struct {
+	pixaTemplData
+}
+
+func (td DupesCmdReportFileParamUsageTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "dupes-cmd-report-file.param-usage",
+		Description: "dupes command report file flag usage",
+		Other:       "report-file is the path of the json file to write the duplicates to",
+	}
+}
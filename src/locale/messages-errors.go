@@ -55,3 +55,626 @@ func NewFooBarError(path string, reason error) FooBarError {
 		},
 	}
 }
+
+// ❌ QualityBelowThreshold
+
+// QualityBelowThresholdTemplData
+type QualityBelowThresholdTemplData struct {
+	pixaTemplData
+	Path      string
+	SSIM      float64
+	Threshold float64
+}
+
+func (td QualityBelowThresholdTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "quality-below-threshold.error",
+		Description: "result rejected because its quality is below the minimum required",
+		Other:       "result '{{.Path}}' rejected, ssim: {{.SSIM}} is below minimum: {{.Threshold}}",
+	}
+}
+
+// QualityBelowThresholdErrorBehaviourQuery used to query if an error is:
+// "result rejected because its quality is below the minimum required"
+type QualityBelowThresholdErrorBehaviourQuery interface {
+	QualityBelowThreshold() bool
+}
+
+type QualityBelowThresholdError struct {
+	xi18n.LocalisableError
+}
+
+// QualityBelowThreshold enables the client to check if error is
+// QualityBelowThresholdError via QualityBelowThresholdErrorBehaviourQuery
+func (e QualityBelowThresholdError) QualityBelowThreshold() bool {
+	return true
+}
+
+// NewQualityBelowThresholdError creates a QualityBelowThresholdError
+func NewQualityBelowThresholdError(path string, ssim, threshold float64) QualityBelowThresholdError {
+	return QualityBelowThresholdError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: QualityBelowThresholdTemplData{
+				Path:      path,
+				SSIM:      ssim,
+				Threshold: threshold,
+			},
+		},
+	}
+}
+
+// ❌ TargetSizeNotReached
+
+// TargetSizeNotReachedTemplData
+type TargetSizeNotReachedTemplData struct {
+	pixaTemplData
+	Path     string
+	Target   int64
+	Smallest int64
+}
+
+func (td TargetSizeNotReachedTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "target-size-not-reached.error",
+		Description: "no quality setting produced a result within the target size",
+		Other:       "result '{{.Path}}' could not be made to fit target size: {{.Target}} bytes, smallest attempt: {{.Smallest}} bytes",
+	}
+}
+
+// TargetSizeNotReachedErrorBehaviourQuery used to query if an error is:
+// "no quality setting produced a result within the target size"
+type TargetSizeNotReachedErrorBehaviourQuery interface {
+	TargetSizeNotReached() bool
+}
+
+type TargetSizeNotReachedError struct {
+	xi18n.LocalisableError
+}
+
+// TargetSizeNotReached enables the client to check if error is
+// TargetSizeNotReachedError via TargetSizeNotReachedErrorBehaviourQuery
+func (e TargetSizeNotReachedError) TargetSizeNotReached() bool {
+	return true
+}
+
+// NewTargetSizeNotReachedError creates a TargetSizeNotReachedError
+func NewTargetSizeNotReachedError(path string, target int64, smallest int64) TargetSizeNotReachedError {
+	return TargetSizeNotReachedError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: TargetSizeNotReachedTemplData{
+				Path:     path,
+				Target:   target,
+				Smallest: smallest,
+			},
+		},
+	}
+}
+
+// ❌ InvalidTargetSize
+
+// InvalidTargetSizeTemplData
+type InvalidTargetSizeTemplData struct {
+	pixaTemplData
+	Size string
+}
+
+func (td InvalidTargetSizeTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "invalid-target-size.error",
+		Description: "target size is not a valid size",
+		Other:       "invalid target size: '{{.Size}}' (eg 500KB, 1.5MiB or a number of bytes)",
+	}
+}
+
+// InvalidTargetSizeErrorBehaviourQuery used to query if an error is:
+// "target size is not a valid size"
+type InvalidTargetSizeErrorBehaviourQuery interface {
+	InvalidTargetSize() bool
+}
+
+type InvalidTargetSizeError struct {
+	xi18n.LocalisableError
+}
+
+// InvalidTargetSize enables the client to check if error is
+// InvalidTargetSizeError via InvalidTargetSizeErrorBehaviourQuery
+func (e InvalidTargetSizeError) InvalidTargetSize() bool {
+	return true
+}
+
+// NewInvalidTargetSizeError creates an InvalidTargetSizeError
+func NewInvalidTargetSizeError(size string) InvalidTargetSizeError {
+	return InvalidTargetSizeError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: InvalidTargetSizeTemplData{
+				Size: size,
+			},
+		},
+	}
+}
+
+// ❌ UnknownProfileFlag
+
+// UnknownProfileFlagTemplData
+type UnknownProfileFlagTemplData struct {
+	pixaTemplData
+	Profile string
+	Flag    string
+}
+
+func (td UnknownProfileFlagTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "unknown-profile-flag.error",
+		Description: "profile contains a flag that is not known",
+		Other:       "profile: '{{.Profile}}' contains unknown flag: '{{.Flag}}' (if it is a valid magick option, add it to advanced.executable.extra-flags)",
+	}
+}
+
+// UnknownProfileFlagErrorBehaviourQuery used to query if an error is:
+// "profile contains a flag that is not known"
+type UnknownProfileFlagErrorBehaviourQuery interface {
+	UnknownProfileFlag() bool
+}
+
+type UnknownProfileFlagError struct {
+	xi18n.LocalisableError
+}
+
+// UnknownProfileFlag enables the client to check if error is
+// UnknownProfileFlagError via UnknownProfileFlagErrorBehaviourQuery
+func (e UnknownProfileFlagError) UnknownProfileFlag() bool {
+	return true
+}
+
+// NewUnknownProfileFlagError creates an UnknownProfileFlagError
+func NewUnknownProfileFlagError(profile string, flag string) UnknownProfileFlagError {
+	return UnknownProfileFlagError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: UnknownProfileFlagTemplData{
+				Profile: profile,
+				Flag:    flag,
+			},
+		},
+	}
+}
+
+// ❌ InvalidProfileFlagValue
+
+// InvalidProfileFlagValueTemplData
+type InvalidProfileFlagValueTemplData struct {
+	pixaTemplData
+	Profile  string
+	Flag     string
+	Value    string
+	Expected string
+}
+
+func (td InvalidProfileFlagValueTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "invalid-profile-flag-value.error",
+		Description: "profile contains a flag with an invalid value",
+		Other:       "profile: '{{.Profile}}' contains flag: '{{.Flag}}' with invalid value: '{{.Value}}' (expected: {{.Expected}})",
+	}
+}
+
+// InvalidProfileFlagValueErrorBehaviourQuery used to query if an error is:
+// "profile contains a flag with an invalid value"
+type InvalidProfileFlagValueErrorBehaviourQuery interface {
+	InvalidProfileFlagValue() bool
+}
+
+type InvalidProfileFlagValueError struct {
+	xi18n.LocalisableError
+}
+
+// InvalidProfileFlagValue enables the client to check if error is
+// InvalidProfileFlagValueError via InvalidProfileFlagValueErrorBehaviourQuery
+func (e InvalidProfileFlagValueError) InvalidProfileFlagValue() bool {
+	return true
+}
+
+// NewInvalidProfileFlagValueError creates an InvalidProfileFlagValueError
+func NewInvalidProfileFlagValueError(profile string, flag string, value string, expected string) InvalidProfileFlagValueError {
+	return InvalidProfileFlagValueError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: InvalidProfileFlagValueTemplData{
+				Profile:  profile,
+				Flag:     flag,
+				Value:    value,
+				Expected: expected,
+			},
+		},
+	}
+}
+
+// ❌ InvalidConfig
+
+// InvalidConfigTemplData
+type InvalidConfigTemplData struct {
+	pixaTemplData
+	Source string
+	Reason error
+}
+
+func (td InvalidConfigTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "invalid-config.error",
+		Description: "config could not be loaded",
+		Other:       "invalid config: '{{.Source}}' (reason: {{.Reason}})",
+	}
+}
+
+// InvalidConfigErrorBehaviourQuery used to query if an error is:
+// "config could not be loaded"
+type InvalidConfigErrorBehaviourQuery interface {
+	InvalidConfig() bool
+}
+
+type InvalidConfigError struct {
+	xi18n.LocalisableError
+}
+
+// InvalidConfig enables the client to check if error is
+// InvalidConfigError via InvalidConfigErrorBehaviourQuery
+func (e InvalidConfigError) InvalidConfig() bool {
+	return true
+}
+
+// NewInvalidConfigError creates an InvalidConfigError
+func NewInvalidConfigError(source string, reason error) InvalidConfigError {
+	return InvalidConfigError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: InvalidConfigTemplData{
+				Source: source,
+				Reason: reason,
+			},
+		},
+	}
+}
+
+// ❌ UnresolvedHomePath
+
+// UnresolvedHomePathTemplData
+type UnresolvedHomePathTemplData struct {
+	pixaTemplData
+	Reason error
+}
+
+func (td UnresolvedHomePathTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "unresolved-home-path.error",
+		Description: "home path could not be resolved",
+		Other:       "can't resolve home path (reason: {{.Reason}})",
+	}
+}
+
+// UnresolvedHomePathErrorBehaviourQuery used to query if an error is:
+// "home path could not be resolved"
+type UnresolvedHomePathErrorBehaviourQuery interface {
+	UnresolvedHomePath() bool
+}
+
+type UnresolvedHomePathError struct {
+	xi18n.LocalisableError
+}
+
+// UnresolvedHomePath enables the client to check if error is
+// UnresolvedHomePathError via UnresolvedHomePathErrorBehaviourQuery
+func (e UnresolvedHomePathError) UnresolvedHomePath() bool {
+	return true
+}
+
+// NewUnresolvedHomePathError creates an UnresolvedHomePathError
+func NewUnresolvedHomePathError(reason error) UnresolvedHomePathError {
+	return UnresolvedHomePathError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: UnresolvedHomePathTemplData{
+				Reason: reason,
+			},
+		},
+	}
+}
+
+// ❌ LogFile
+
+// LogFileTemplData
+type LogFileTemplData struct {
+	pixaTemplData
+	Path   string
+	Reason error
+}
+
+func (td LogFileTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "log-file.error",
+		Description: "log file could not be created",
+		Other:       "can't create log file: '{{.Path}}' (reason: {{.Reason}})",
+	}
+}
+
+// LogFileErrorBehaviourQuery used to query if an error is:
+// "log file could not be created"
+type LogFileErrorBehaviourQuery interface {
+	LogFile() bool
+}
+
+type LogFileError struct {
+	xi18n.LocalisableError
+}
+
+// LogFile enables the client to check if error is
+// LogFileError via LogFileErrorBehaviourQuery
+func (e LogFileError) LogFile() bool {
+	return true
+}
+
+// NewLogFileError creates a LogFileError
+func NewLogFileError(path string, reason error) LogFileError {
+	return LogFileError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: LogFileTemplData{
+				Path:   path,
+				Reason: reason,
+			},
+		},
+	}
+}
+
+// ❌ MissingExecutable
+
+// MissingExecutableTemplData
+type MissingExecutableTemplData struct {
+	pixaTemplData
+	Program string
+}
+
+func (td MissingExecutableTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "missing-executable.error",
+		Description: "program to execute is not installed",
+		Other:       "executable: '{{.Program}}' not found, is it installed and on the PATH?",
+	}
+}
+
+// MissingExecutableErrorBehaviourQuery used to query if an error is:
+// "program to execute is not installed"
+type MissingExecutableErrorBehaviourQuery interface {
+	MissingExecutable() bool
+}
+
+type MissingExecutableError struct {
+	xi18n.LocalisableError
+}
+
+// MissingExecutable enables the client to check if error is
+// MissingExecutableError via MissingExecutableErrorBehaviourQuery
+func (e MissingExecutableError) MissingExecutable() bool {
+	return true
+}
+
+// NewMissingExecutableError creates a MissingExecutableError
+func NewMissingExecutableError(program string) MissingExecutableError {
+	return MissingExecutableError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: MissingExecutableTemplData{
+				Program: program,
+			},
+		},
+	}
+}
+
+// ❌ UserAbort
+
+// UserAbortTemplData
+type UserAbortTemplData struct {
+	pixaTemplData
+}
+
+func (td UserAbortTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "user-abort.error",
+		Description: "user aborted the run before it completed",
+		Other:       "aborted by user",
+	}
+}
+
+// UserAbortErrorBehaviourQuery used to query if an error is:
+// "user aborted the run before it completed"
+type UserAbortErrorBehaviourQuery interface {
+	UserAbort() bool
+}
+
+type UserAbortError struct {
+	xi18n.LocalisableError
+}
+
+// UserAbort enables the client to check if error is
+// UserAbortError via UserAbortErrorBehaviourQuery
+func (e UserAbortError) UserAbort() bool {
+	return true
+}
+
+// NewUserAbortError creates a UserAbortError
+func NewUserAbortError() UserAbortError {
+	return UserAbortError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: UserAbortTemplData{},
+		},
+	}
+}
+
+// ❌ PartialFailure
+
+// PartialFailureTemplData
+type PartialFailureTemplData struct {
+	pixaTemplData
+	Failed int
+	Total  int
+}
+
+func (td PartialFailureTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "partial-failure.error",
+		Description: "run completed but some of its outcomes failed",
+		Other:       "{{.Failed}} of {{.Total}} outcome(s) failed",
+	}
+}
+
+// PartialFailureErrorBehaviourQuery used to query if an error is:
+// "run completed but some of its outcomes failed"
+type PartialFailureErrorBehaviourQuery interface {
+	PartialFailure() bool
+}
+
+type PartialFailureError struct {
+	xi18n.LocalisableError
+}
+
+// PartialFailure enables the client to check if error is
+// PartialFailureError via PartialFailureErrorBehaviourQuery
+func (e PartialFailureError) PartialFailure() bool {
+	return true
+}
+
+// NewPartialFailureError creates a PartialFailureError
+func NewPartialFailureError(failed int, total int) PartialFailureError {
+	return PartialFailureError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: PartialFailureTemplData{
+				Failed: failed,
+				Total:  total,
+			},
+		},
+	}
+}
+
+// ❌ MaxFailuresReached
+
+// MaxFailuresReachedTemplData
+type MaxFailuresReachedTemplData struct {
+	pixaTemplData
+	Limit uint
+}
+
+func (td MaxFailuresReachedTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "max-failures-reached.error",
+		Description: "run aborted because the maximum number of failures was reached",
+		Other:       "run aborted after {{.Limit}} failure(s)",
+	}
+}
+
+// MaxFailuresReachedErrorBehaviourQuery used to query if an error is:
+// "run aborted because the maximum number of failures was reached"
+type MaxFailuresReachedErrorBehaviourQuery interface {
+	MaxFailuresReached() bool
+}
+
+type MaxFailuresReachedError struct {
+	xi18n.LocalisableError
+}
+
+// MaxFailuresReached enables the client to check if error is
+// MaxFailuresReachedError via MaxFailuresReachedErrorBehaviourQuery
+func (e MaxFailuresReachedError) MaxFailuresReached() bool {
+	return true
+}
+
+// NewMaxFailuresReachedError creates a MaxFailuresReachedError
+func NewMaxFailuresReachedError(limit uint) MaxFailuresReachedError {
+	return MaxFailuresReachedError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: MaxFailuresReachedTemplData{
+				Limit: limit,
+			},
+		},
+	}
+}
+
+// ❌ InputChanged
+
+// InputChangedTemplData
+type InputChangedTemplData struct {
+	pixaTemplData
+	Path string
+}
+
+func (td InputChangedTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "input-changed.error",
+		Description: "input has changed since the plan was created",
+		Other:       "input '{{.Path}}' has changed since it was planned",
+	}
+}
+
+// InputChangedErrorBehaviourQuery used to query if an error is:
+// "input has changed since the plan was created"
+type InputChangedErrorBehaviourQuery interface {
+	InputChanged() bool
+}
+
+type InputChangedError struct {
+	xi18n.LocalisableError
+}
+
+// InputChanged enables the client to check if error is
+// InputChangedError via InputChangedErrorBehaviourQuery
+func (e InputChangedError) InputChanged() bool {
+	return true
+}
+
+// NewInputChangedError creates an InputChangedError
+func NewInputChangedError(path string) InputChangedError {
+	return InputChangedError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: InputChangedTemplData{
+				Path: path,
+			},
+		},
+	}
+}
+
+// ❌ InvalidPlan
+
+// InvalidPlanTemplData
+type InvalidPlanTemplData struct {
+	pixaTemplData
+	Path   string
+	Reason error
+}
+
+func (td InvalidPlanTemplData) Message() *i18n.Message {
+	return &i18n.Message{
+		ID:          "invalid-plan.error",
+		Description: "plan file could not be applied",
+		Other:       "invalid plan '{{.Path}}': {{.Reason}}",
+	}
+}
+
+// InvalidPlanErrorBehaviourQuery used to query if an error is:
+// "plan file could not be applied"
+type InvalidPlanErrorBehaviourQuery interface {
+	InvalidPlan() bool
+}
+
+type InvalidPlanError struct {
+	xi18n.LocalisableError
+}
+
+// InvalidPlan enables the client to check if error is
+// InvalidPlanError via InvalidPlanErrorBehaviourQuery
+func (e InvalidPlanError) InvalidPlan() bool {
+	return true
+}
+
+// NewInvalidPlanError creates an InvalidPlanError
+func NewInvalidPlanError(path string, reason error) InvalidPlanError {
+	return InvalidPlanError{
+		LocalisableError: xi18n.LocalisableError{
+			Data: InvalidPlanTemplData{
+				Path:   path,
+				Reason: reason,
+			},
+		},
+	}
+}